@@ -53,11 +53,11 @@ var CreateAWSProject = cli.Command{
 		m, cfg := setup(c)
 
 		if len(c.Args()) < 1 {
-			h.PrintError("You should specify the project name")
+			h.PrintUsageError("You should specify the project name")
 		}
 
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 		name := c.Args()[0]
 
@@ -65,7 +65,7 @@ var CreateAWSProject = cli.Command{
 		if template != "" {
 			var t model.ProjectTemplate
 			if err := getProjectTemplate(template, &t); err != nil {
-				h.PrintError(err.Error())
+				h.Fail(err)
 			}
 			accessKeyID = t.Token
 			secretAccessKey = t.Secret
@@ -102,7 +102,7 @@ var CreateAWSProject = cli.Command{
 			for _, e := range errs {
 				msgs = append(msgs, "  - "+e)
 			}
-			h.PrintError(strings.Join(msgs, "\n"))
+			h.PrintUsageError(strings.Join(msgs, "\n"))
 		}
 
 		rtype := "aws"
@@ -112,7 +112,7 @@ var CreateAWSProject = cli.Command{
 		}
 		body, err := m.CreateAWSProject(cfg.Token, name, rtype, region, accessKeyID, secretAccessKey)
 		if err != nil {
-			h.PrintError(body)
+			h.Fail(h.WithMessage(err, body))
 		} else {
 			color.Green("Project '" + name + "' successfully created ")
 		}
@@ -142,26 +142,26 @@ var UpdateAWSProject = cli.Command{
 		var accessKeyID, secretAccessKey string
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if len(c.Args()) == 0 {
-			h.PrintError("You should specify the project name")
+			h.PrintUsageError("You should specify the project name")
 		}
 		name := c.Args()[0]
 		accessKeyID = c.String("access_key_id")
 		secretAccessKey = c.String("secret_access_key")
 
 		if accessKeyID == "" {
-			h.PrintError("You should specify your aws access key id with '--access_key_id' flag")
+			h.PrintUsageError("You should specify your aws access key id with '--access_key_id' flag")
 		}
 		if secretAccessKey == "" {
-			h.PrintError("You should specify your aws secret access key with '--secret_access_key' flag")
+			h.PrintUsageError("You should specify your aws secret access key with '--secret_access_key' flag")
 		}
 
 		err := m.UpdateAWSProject(cfg.Token, name, accessKeyID, secretAccessKey)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 		color.Green("Project " + name + " successfully updated")
 
@@ -62,11 +62,11 @@ var CreateAzureProject = cli.Command{
 		m, cfg := setup(c)
 
 		if len(c.Args()) < 1 {
-			h.PrintError("You should specify the project name")
+			h.PrintUsageError("You should specify the project name")
 		}
 
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 		name := c.Args()[0]
 
@@ -75,8 +75,7 @@ var CreateAzureProject = cli.Command{
 			/*
 				var t model.ProjectTemplate
 				if err := getProjectTemplate(template, &t); err != nil {
-					color.Red(err.Error())
-					return nil
+					h.Fail(err)
 				}
 				accessKeyID = t.Token
 				secretAccessKey = t.Secret
@@ -127,7 +126,7 @@ var CreateAzureProject = cli.Command{
 			for _, e := range errs {
 				msgs = append(msgs, "  - "+e)
 			}
-			h.PrintError(strings.Join(msgs, "\n"))
+			h.PrintUsageError(strings.Join(msgs, "\n"))
 		}
 		rtype := "azure"
 
@@ -136,7 +135,7 @@ var CreateAzureProject = cli.Command{
 		}
 		body, err := m.CreateAzureProject(cfg.Token, name, rtype, region, subscriptionID, clientID, clientSecret, tenantID, environment)
 		if err != nil {
-			h.PrintError(body)
+			h.Fail(h.WithMessage(err, body))
 		} else {
 			color.Green("Project '" + name + "' successfully created ")
 		}
@@ -182,13 +181,11 @@ var UpdateAzureProject = cli.Command{
 		var subscriptionID, clientID, clientSecret, tenantID, environment string
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			color.Red("You're not allowed to perform this action, please log in")
-			return nil
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if len(c.Args()) == 0 {
-			color.Red("You should specify the project name")
-			return nil
+			h.PrintUsageError("You should specify the project name")
 		}
 		name := c.Args()[0]
 		if c.String("subscription_id") != "" {
@@ -228,13 +225,12 @@ var UpdateAzureProject = cli.Command{
 			for _, e := range errs {
 				msgs = append(msgs, "  - "+e)
 			}
-			h.PrintError(strings.Join(msgs, "\n"))
+			h.PrintUsageError(strings.Join(msgs, "\n"))
 		}
 
 		err := m.UpdateAzureProject(cfg.Token, name, subscriptionID, clientID, clientSecret, tenantID, environment)
 		if err != nil {
-			color.Red(err.Error())
-			return nil
+			h.Fail(err)
 		}
 		color.Green("Project " + name + " successfully updated")
 
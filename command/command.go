@@ -5,8 +5,11 @@
 package command
 
 import (
+	"bufio"
 	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	h "github.com/ernestio/ernest-cli/helper"
 	"github.com/ernestio/ernest-cli/manager"
@@ -20,10 +23,10 @@ func setup(c *cli.Context) (*manager.Manager, *model.Config) {
 	if config == nil {
 		config = &model.Config{}
 		if c.Command.Name != "target" && c.Command.Name != "setup" {
-			h.PrintError("Environment not configured, please use target command")
+			h.Fail(h.NewError(h.ExitUsage, "Environment not configured, please use target command"))
 		}
 	}
-	m := manager.Manager{URL: config.URL, Version: c.App.Version}
+	m := manager.Manager{URL: config.URL, Version: c.App.Version, Monitor: monitorOptions(c), Hooks: config.Hooks}
 	return &m, config
 }
 
@@ -49,3 +52,32 @@ func askForConfirmation() bool {
 		return askForConfirmation()
 	}
 }
+
+// askForTypedConfirmation : reads a line from the user, returning true only
+// when it is the expected text
+func askForTypedConfirmation(expected string) bool {
+	response, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && response == "" {
+		return false
+	}
+	return strings.TrimSpace(response) == expected
+}
+
+// askForValue : asks the user for a value, returning the default one when
+// nothing is typed
+func askForValue(label, value string) string {
+	if value != "" {
+		fmt.Print(label + " [" + value + "]: ")
+	} else {
+		fmt.Print(label + ": ")
+	}
+
+	response, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && response == "" {
+		return value
+	}
+	if response = strings.TrimSpace(response); response != "" {
+		return response
+	}
+	return value
+}
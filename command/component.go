@@ -27,15 +27,15 @@ var FindComponents = cli.Command{
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if len(c.Args()) == 0 {
-			h.PrintError("You should specify an existing project name")
+			h.PrintUsageError("You should specify an existing project name")
 		}
 
 		if len(c.Args()) == 1 {
-			h.PrintError("You should specify the component type")
+			h.PrintUsageError("You should specify the component type")
 		}
 
 		project := c.Args()[0]
@@ -43,7 +43,7 @@ var FindComponents = cli.Command{
 		service := c.String("environment")
 		components, err := m.FindComponents(cfg.Token, project, component, service)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 		view.PrintComponentsList(components)
 
@@ -0,0 +1,107 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package command
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+
+	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/ernestio/ernest-cli/view"
+	"github.com/urfave/cli"
+)
+
+// ConvertTerraform : Translates the aws resources of a terraform
+// configuration to a definition
+var ConvertTerraform = cli.Command{
+	Name:        "terraform",
+	Usage:       h.T("convert.terraform.usage"),
+	ArgsUsage:   h.T("convert.terraform.args"),
+	Description: h.T("convert.terraform.description"),
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "name",
+			Value: "",
+			Usage: "Environment name of the definition, the directory name by default",
+		},
+		cli.StringFlag{
+			Name:  "project",
+			Value: "",
+			Usage: "Project name of the definition",
+		},
+		cli.StringFlag{
+			Name:  "output",
+			Value: "ernest.yml",
+			Usage: "Definition file to write",
+		},
+	}, ExportFlags...),
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) < 1 {
+			h.PrintUsageError("You should specify the terraform configuration directory")
+		}
+		if c.String("project") == "" {
+			h.PrintUsageError("You should specify the project of the definition with --project")
+		}
+
+		dir := c.Args()[0]
+		name := c.String("name")
+		if name == "" {
+			abs, err := filepath.Abs(dir)
+			if err != nil {
+				h.Fail(err)
+			}
+			name = filepath.Base(abs)
+		}
+
+		files, err := readTerraformFiles(dir)
+		if err != nil {
+			h.Fail(err)
+		}
+
+		d, report, err := model.ConvertTerraform(name, c.String("project"), files)
+		if err != nil {
+			h.Fail(err)
+		}
+
+		checkExportFile(c, c.String("output"))
+		writeDefinition(c, d, c.String("output"))
+		view.PrintConversionReport(report)
+
+		return nil
+	},
+}
+
+// readTerraformFiles : reads the configuration and variables files of a
+// terraform directory, on name order
+func readTerraformFiles(dir string) ([]model.TerraformFile, error) {
+	var files []model.TerraformFile
+
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if len(paths) == 0 {
+		return nil, h.NewError(h.ExitNotFound, "No terraform configuration files found on "+dir)
+	}
+	vars, _ := filepath.Glob(filepath.Join(dir, "*.tfvars"))
+
+	for _, path := range append(paths, vars...) {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.New("Can't read file " + path)
+		}
+		files = append(files, model.TerraformFile{Path: path, Content: content})
+	}
+
+	return files, nil
+}
+
+// CmdConvert ...
+var CmdConvert = cli.Command{
+	Name:  "convert",
+	Usage: "Converts other tools configurations to definitions",
+	Subcommands: []cli.Command{
+		ConvertTerraform,
+	},
+}
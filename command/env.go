@@ -7,13 +7,23 @@ package command
 // CmdProject subcommand
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/manager"
 	"github.com/ernestio/ernest-cli/model"
 	"github.com/ernestio/ernest-cli/view"
 	"github.com/fatih/color"
+	"github.com/gosuri/uilive"
+	isatty "github.com/mattn/go-isatty"
 	"github.com/urfave/cli"
 )
 
@@ -26,11 +36,11 @@ var ListEnvs = cli.Command{
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 		envs, err := m.ListEnvs(cfg.Token)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
 		view.PrintEnvList(envs)
@@ -49,21 +59,21 @@ var UpdateEnv = cli.Command{
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if len(c.Args()) < 1 {
-			h.PrintError("You must provide the project name")
+			h.PrintUsageError("You must provide the project name")
 		}
 		if len(c.Args()) < 2 {
-			h.PrintError("You must provide the new environment name")
+			h.PrintUsageError("You must provide the new environment name")
 		}
 		project := c.Args()[0]
 		env := c.Args()[1]
 
 		err := m.UpdateEnv(cfg.Token, env, project, ProviderFlagsToSlice(c))
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
 		color.Green("Environment successfully updated")
@@ -88,21 +98,21 @@ var CreateEnv = cli.Command{
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if len(c.Args()) < 1 {
-			h.PrintError("You must provide the project name")
+			h.PrintUsageError("You must provide the project name")
 		}
 		if len(c.Args()) < 2 {
-			h.PrintError("You must provide the new environment name")
+			h.PrintUsageError("You must provide the new environment name")
 		}
 		project := c.Args()[0]
 		env := c.Args()[1]
 
 		err := m.CreateEnv(cfg.Token, env, project, ProviderFlagsToSlice(c))
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 		color.Green("Environment successfully created")
 
@@ -127,7 +137,15 @@ var ApplyEnv = cli.Command{
 			Name:  "credentials",
 			Usage: "will override project information",
 		},
-	}, AllProviderFlags...),
+		cli.BoolFlag{
+			Name:  "git",
+			Usage: "Attach the git commit, branch and remote of the definition file to the build",
+		},
+		cli.StringFlag{
+			Name:  "message",
+			Usage: "Attach a message to the build",
+		},
+	}, append(AllProviderFlags, MonitorFlags...)...),
 	Action: func(c *cli.Context) error {
 		file := "ernest.yml"
 		if len(c.Args()) == 1 {
@@ -135,7 +153,7 @@ var ApplyEnv = cli.Command{
 		}
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		var err error
@@ -144,9 +162,17 @@ var ApplyEnv = cli.Command{
 		if dry == true {
 			monit = false
 		}
-		response, err := m.Apply(cfg.Token, file, ProviderFlagsToSlice(c), monit, dry)
+		metadata, err := buildMetadata(c, file)
+		if err != nil {
+			h.Fail(err)
+		}
+
+		response, err := m.Apply(cfg.Token, file, ProviderFlagsToSlice(c), metadata, monit, dry)
+		if err == h.ErrChangesPending {
+			os.Exit(h.ExitChangesPending)
+		}
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 		if dry == true {
 			fmt.Println(string(response))
@@ -155,6 +181,271 @@ var ApplyEnv = cli.Command{
 	},
 }
 
+// buildMetadata : the metadata to attach to a build, read from the flags and
+// the git working tree of the definition file
+func buildMetadata(c *cli.Context, file string) (map[string]string, error) {
+	metadata := make(map[string]string)
+
+	if message := c.String("message"); message != "" {
+		metadata[model.MetadataMessage] = message
+	}
+
+	if !c.Bool("git") {
+		return metadata, nil
+	}
+
+	info, err := h.GitWorkTree(filepath.Dir(file))
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, errors.New("The definition file " + file + " is not on a git repository")
+	}
+
+	values := map[string]string{
+		model.MetadataGitCommit: info.Commit,
+		model.MetadataGitBranch: info.Branch,
+		model.MetadataGitDirty:  strconv.FormatBool(info.Dirty),
+		model.MetadataGitRemote: info.Remote,
+	}
+	for k, v := range values {
+		if v != "" {
+			metadata[k] = v
+		}
+	}
+
+	return metadata, nil
+}
+
+// WatchEnv command
+// Dry runs a definition every time it or the files it references change
+var WatchEnv = cli.Command{
+	Name:        "watch",
+	Usage:       h.T("envs.watch.usage"),
+	ArgsUsage:   h.T("envs.watch.args"),
+	Description: h.T("envs.watch.description"),
+	Flags: append([]cli.Flag{
+		cli.BoolFlag{
+			Name:  "apply",
+			Usage: "Apply the changes, only on sandbox environments",
+		},
+		cli.DurationFlag{
+			Name:  "interval",
+			Value: 500 * time.Millisecond,
+			Usage: "How often files are checked for changes",
+		},
+		cli.DurationFlag{
+			Name:  "debounce",
+			Value: time.Second,
+			Usage: "How long files should stay unchanged before dry running them",
+		},
+		IdleTimeoutFlag,
+	}, ProgressFlags...),
+	Action: func(c *cli.Context) error {
+		file := "ernest.yml"
+		if len(c.Args()) == 1 {
+			file = c.Args()[0]
+		}
+		m, cfg := setup(c)
+		if cfg.Token == "" {
+			h.Fail(h.ErrNotLoggedIn)
+		}
+		if _, err := os.Stat(file); err != nil {
+			h.PrintUsageError("You should specify a valid template path or store an ernest.yml on the current folder")
+		}
+
+		watcher := h.NewFileWatcher(c.Duration("interval"), c.Duration("debounce"))
+		writer := uilive.New()
+		writer.Start()
+
+		applied := false
+		for {
+			d, s := checkWatchedDefinition(m, cfg, file)
+			watcher.Watch(append([]string{file}, s.Imports...))
+
+			apply := c.Bool("apply") && s.Err == nil && len(s.Changes) > 0 && !applied
+			if c.Bool("apply") && s.Err == nil && !cfg.IsSandbox(d.Project, d.Name) {
+				s.Note = color.YellowString("Changes are not applied, " + s.Env + " is not a sandbox environment")
+				apply = false
+			}
+
+			view.PrintWatchStatus(writer, s)
+			_ = writer.Flush()
+
+			if apply {
+				writer.Stop()
+				if err := applyWatchedDefinition(m, cfg, d); err != nil {
+					color.Red(err.Error())
+				}
+				writer = uilive.New()
+				writer.Start()
+
+				// show the outcome, applying again only after files change
+				applied = true
+				continue
+			}
+
+			applied = false
+			watcher.Wait()
+		}
+	},
+}
+
+// checkWatchedDefinition : validates a definition locally and dry runs it
+func checkWatchedDefinition(m *manager.Manager, cfg *model.Config, file string) (model.Definition, view.WatchStatus) {
+	var d model.Definition
+	s := view.WatchStatus{File: file, At: time.Now()}
+
+	payload, err := ioutil.ReadFile(file)
+	if err != nil {
+		s.Err = errors.New("Can't read " + file)
+		return d, s
+	}
+
+	if err = d.Load(payload); err != nil {
+		s.Err = errors.New("Could not process definition yaml: " + err.Error())
+		return d, s
+	}
+	d.ResolveFileImports(filepath.Dir(file))
+	s.Imports = d.FileImports()
+	s.Env = d.Project + "/" + d.Name
+
+	if err = d.Validate(); err != nil {
+		s.Err = err
+		return d, s
+	}
+
+	if err = d.LoadFileImports(); err != nil {
+		s.Err = err
+		return d, s
+	}
+
+	s.Changes, s.Err = m.DryApplyEnv(cfg.Token, d)
+
+	return d, s
+}
+
+// applyWatchedDefinition : applies a watched definition, creating its
+// environment when it doesn't exist
+func applyWatchedDefinition(m *manager.Manager, cfg *model.Config, d model.Definition) error {
+	if _, err := m.EnvStatus(cfg.Token, d.Project, d.Name); err != nil {
+		if err := m.CreateEnv(cfg.Token, d.Name, d.Project, nil); err != nil {
+			return err
+		}
+	}
+
+	_, err := m.ApplyEnv(d, cfg.Token, nil, true, false)
+	return err
+}
+
+// InitEnv command
+// Generates a starter definition for a provider
+var InitEnv = cli.Command{
+	Name:        "init",
+	Usage:       h.T("envs.init.usage"),
+	ArgsUsage:   h.T("envs.init.args"),
+	Description: h.T("envs.init.description"),
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "provider",
+			Usage: "Provider of the environment: " + strings.Join(h.ScaffoldProviders, ", "),
+		},
+		cli.StringFlag{
+			Name:  "preset",
+			Usage: "Components of the environment: " + strings.Join(h.ScaffoldPresets, ", "),
+		},
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "Environment name",
+		},
+		cli.StringFlag{
+			Name:  "project",
+			Usage: "Project name",
+		},
+		cli.StringFlag{
+			Name:  "cidr",
+			Usage: "Address range of the vpc or virtual network",
+		},
+		cli.StringFlag{
+			Name:  "subnet",
+			Usage: "Address range of the web network",
+		},
+		cli.IntFlag{
+			Name:  "count",
+			Usage: "Number of web instances",
+		},
+		cli.StringFlag{
+			Name:  "dir",
+			Value: ".",
+			Usage: "Folder to write the definition to",
+		},
+		cli.BoolFlag{
+			Name:  "overwrite",
+			Usage: "Overwrite the files if they exist",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		interactive := isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd())
+		ask := func(flag, label, value string) string {
+			if c.String(flag) != "" {
+				return c.String(flag)
+			}
+			if !interactive {
+				return value
+			}
+			return askForValue(label, value)
+		}
+
+		opts := h.ScaffoldOptions{Dir: c.String("dir")}
+		opts.Provider = ask("provider", "Provider ("+strings.Join(h.ScaffoldProviders, ", ")+")", "aws")
+		opts.Preset = ask("preset", "Preset ("+strings.Join(h.ScaffoldPresets, ", ")+")", h.PRESETWEBAPP)
+		opts.Project = ask("project", "Project name", "")
+		opts.Name = ask("name", "Environment name", "")
+		if opts.Provider == "vcloud" {
+			opts.CIDR = "10.0.0.0/16"
+		} else {
+			opts.CIDR = ask("cidr", "Network range", "10.0.0.0/16")
+		}
+		opts.Subnet = ask("subnet", "Web subnet", "10.0.1.0/24")
+
+		opts.Count = 2
+		if c.Int("count") != 0 {
+			opts.Count = c.Int("count")
+		} else if interactive && opts.Preset == h.PRESETWEBAPP {
+			count, err := strconv.Atoi(askForValue("Web instances", "2"))
+			if err != nil {
+				h.PrintUsageError("The number of web instances should be a number")
+			}
+			opts.Count = count
+		}
+
+		if opts.Name == "" || opts.Project == "" {
+			h.PrintUsageError("You should specify the environment and project names with --name and --project")
+		}
+
+		files, err := h.Scaffold(opts)
+		if err != nil {
+			h.PrintUsageError(err.Error())
+		}
+
+		for _, f := range files {
+			checkExportFile(c, f.Path)
+		}
+
+		if err := os.MkdirAll(opts.Dir, 0755); err != nil {
+			h.Fail(errors.New("Can't create folder " + opts.Dir))
+		}
+		for _, f := range files {
+			if err := ioutil.WriteFile(f.Path, []byte(f.Content), 0644); err != nil {
+				h.Fail(errors.New("Can't write file " + f.Path))
+			}
+			color.Green("Created " + f.Path)
+		}
+
+		return nil
+	},
+}
+
 // DestroyEnv command
 var DestroyEnv = cli.Command{
 	Name:        "delete",
@@ -162,7 +453,7 @@ var DestroyEnv = cli.Command{
 	Usage:       h.T("envs.destroy.usage"),
 	ArgsUsage:   h.T("envs.destroy.args"),
 	Description: h.T("envs.destroy.description"),
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		cli.BoolFlag{
 			Name:  "force,f",
 			Usage: "Hard ernest env removal.",
@@ -171,45 +462,148 @@ var DestroyEnv = cli.Command{
 			Name:  "yes,y",
 			Usage: "Destroy an environment without prompting confirmation.",
 		},
-	},
+		cli.BoolFlag{
+			Name:  "i-know",
+			Usage: "Destroy a protected environment without typing its name.",
+		},
+	}, MonitorFlags...),
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if len(c.Args()) < 1 {
-			h.PrintError("You should specify an existing project name")
+			h.PrintUsageError("You should specify an existing project name")
 		}
 		if len(c.Args()) < 2 {
-			h.PrintError("You should specify an existing project environment")
+			h.PrintUsageError("You should specify an existing project environment")
 		}
 		project := c.Args()[0]
 		env := c.Args()[1]
 
+		if !confirmDestroy(c, m, cfg, project, env) {
+			return nil
+		}
+
 		if c.Bool("force") {
 			err := m.ForceDestroy(cfg.Token, project, env)
 			if err != nil {
-				h.PrintError(err.Error())
+				h.Fail(err)
 			}
 		} else {
-			if c.Bool("yes") {
-				err := m.Destroy(cfg.Token, project, env, true)
-				if err != nil {
-					h.PrintError(err.Error())
-				}
-			} else {
-				fmt.Print("Do you really want to destroy this environment? (Y/n) ")
-				if askForConfirmation() == false {
-					return nil
-				}
-				err := m.Destroy(cfg.Token, project, env, true)
-				if err != nil {
-					h.PrintError(err.Error())
-				}
+			err := m.Destroy(cfg.Token, project, env, true)
+			if err != nil {
+				h.Fail(err)
+			}
+		}
+		if c.String("progress") != h.PROGRESSJSON {
+			color.Green("Environment successfully removed")
+		}
+		return nil
+	},
+}
+
+// confirmDestroy : shows the components destroying an environment deletes and
+// asks to confirm it, typing the environment name when it is protected
+func confirmDestroy(c *cli.Context, m *manager.Manager, cfg *model.Config, project, env string) bool {
+	if c.String("progress") != h.PROGRESSJSON {
+		if b, err := m.LatestBuildStatus(cfg.Token, project, env); err == nil {
+			view.PrintDestroySummary(&b)
+		}
+	}
+
+	name := project + "/" + env
+
+	if cfg.IsProtected(project, env) {
+		if c.Bool("i-know") {
+			color.Yellow("Environment " + name + " is protected, destroying it as --i-know was given")
+			return true
+		}
+
+		color.Yellow("Environment " + name + " is protected")
+		fmt.Print("Type its name, " + name + ", to destroy it: ")
+		if !askForTypedConfirmation(name) {
+			color.Red("The name doesn't match, the environment won't be destroyed")
+			return false
+		}
+		return true
+	}
+
+	if c.Bool("yes") {
+		return true
+	}
+
+	fmt.Print("Do you really want to destroy this environment? (Y/n) ")
+	return askForConfirmation()
+}
+
+// ProtectEnv : Protects the environments matching a pattern from being destroyed
+var ProtectEnv = cli.Command{
+	Name:        "protect",
+	Usage:       h.T("envs.protect.usage"),
+	ArgsUsage:   h.T("envs.protect.args"),
+	Description: h.T("envs.protect.description"),
+	Action: func(c *cli.Context) error {
+		_, cfg := setup(c)
+
+		if len(c.Args()) == 0 {
+			if len(cfg.Protected) == 0 {
+				fmt.Println("There are no protected environments")
+			}
+			for _, pattern := range cfg.Protected {
+				fmt.Println(pattern)
+			}
+			return nil
+		}
+
+		pattern := c.Args()[0]
+		if _, err := path.Match(pattern, ""); err != nil || !strings.Contains(pattern, "/") {
+			h.PrintUsageError("Invalid pattern '" + pattern + "', patterns should match project/env names, like */prod*")
+		}
+
+		if !containsString(cfg.Protected, pattern) {
+			cfg.Protected = append(cfg.Protected, pattern)
+			if err := model.SaveConfig(cfg); err != nil {
+				h.Fail(err)
+			}
+		}
+
+		color.Green("Environments matching " + pattern + " are protected")
+		return nil
+	},
+}
+
+// UnprotectEnv : Removes a protection pattern
+var UnprotectEnv = cli.Command{
+	Name:        "unprotect",
+	Usage:       h.T("envs.unprotect.usage"),
+	ArgsUsage:   h.T("envs.unprotect.args"),
+	Description: h.T("envs.unprotect.description"),
+	Action: func(c *cli.Context) error {
+		_, cfg := setup(c)
+
+		if len(c.Args()) == 0 {
+			h.PrintUsageError("You should specify the pattern to remove")
+		}
+		pattern := c.Args()[0]
+
+		var patterns []string
+		for _, p := range cfg.Protected {
+			if p != pattern {
+				patterns = append(patterns, p)
 			}
 		}
-		color.Green("Environment successfully removed")
+		if len(patterns) == len(cfg.Protected) {
+			h.Fail(h.NewError(h.ExitNotFound, "There is no protection pattern "+pattern))
+		}
+
+		cfg.Protected = patterns
+		if err := model.SaveConfig(cfg); err != nil {
+			h.Fail(err)
+		}
+
+		color.Green("Environments matching " + pattern + " are no longer protected")
 		return nil
 	},
 }
@@ -221,24 +615,126 @@ var HistoryEnv = cli.Command{
 	Usage:       h.T("envs.history.usage"),
 	ArgsUsage:   h.T("envs.history.args"),
 	Description: h.T("envs.history.description"),
+	Subcommands: []cli.Command{
+		ExportHistoryEnv,
+	},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "status",
+			Usage: "Only show builds with the given status (done | errored | in_progress)",
+		},
+		cli.StringFlag{
+			Name:  "user",
+			Usage: "Only show builds created by the given user",
+		},
+		cli.StringFlag{
+			Name:  "since",
+			Usage: "Only show builds created since the given date",
+		},
+		cli.StringFlag{
+			Name:  "until",
+			Usage: "Only show builds created until the given date",
+		},
+		cli.IntFlag{
+			Name:  "limit",
+			Usage: "Number of builds to show per page, all of them by default",
+		},
+		cli.IntFlag{
+			Name:  "page",
+			Value: 1,
+			Usage: "Page of builds to show, used with --limit",
+		},
+		cli.BoolFlag{
+			Name:  "with-changes",
+			Usage: "Show the components every build added, changed and removed",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		m, cfg := setup(c)
+		if cfg.Token == "" {
+			h.Fail(h.ErrNotLoggedIn)
+		}
+
+		if len(c.Args()) < 1 {
+			h.PrintUsageError("You should specify an existing project name")
+		}
+		if len(c.Args()) < 2 {
+			h.PrintUsageError("You should specify an existing environment name")
+		}
+
+		project := c.Args()[0]
+		env := c.Args()[1]
+
+		if status := c.String("status"); status != "" && !containsString([]string{"done", "errored", "in_progress"}, status) {
+			h.PrintUsageError("Invalid status '" + status + "', valid statuses are done, errored and in_progress")
+		}
+		if c.Int("limit") < 0 || c.Int("page") < 1 {
+			h.PrintUsageError("The limit can't be negative and pages start on 1")
+		}
+
+		entries, total, err := m.BuildHistory(cfg.Token, project, env, manager.HistoryOptions{
+			Status:      c.String("status"),
+			User:        c.String("user"),
+			Since:       c.String("since"),
+			Until:       c.String("until"),
+			Limit:       c.Int("limit"),
+			Page:        c.Int("page"),
+			WithChanges: c.Bool("with-changes"),
+		})
+		if err != nil {
+			h.Fail(err)
+		}
+
+		view.PrintEnvHistory(env, entries, total)
+		return nil
+	},
+}
+
+// ExportHistoryEnv : Exports the build history of an environment to a git repository
+var ExportHistoryEnv = cli.Command{
+	Name:        "export",
+	Usage:       h.T("envs.history.export.usage"),
+	ArgsUsage:   h.T("envs.history.export.args"),
+	Description: h.T("envs.history.export.description"),
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if len(c.Args()) < 1 {
-			h.PrintError("You should specify an existing project name")
+			h.PrintUsageError("You should specify an existing project name")
 		}
 		if len(c.Args()) < 2 {
-			h.PrintError("You should specify an existing environment name")
+			h.PrintUsageError("You should specify an existing environment name")
+		}
+		if len(c.Args()) < 3 {
+			h.PrintUsageError("You should specify the folder to export the history to")
 		}
 
 		project := c.Args()[0]
 		env := c.Args()[1]
+		dir := c.Args()[2]
+
+		builds, pending, err := m.ExportHistory(cfg.Token, project, env, dir)
+		for _, b := range builds {
+			fmt.Println("Exported build " + b.ShortID() + " (" + b.CreatedAt + ")")
+		}
+		if err != nil {
+			h.Fail(err)
+		}
+		if pending != nil {
+			color.Yellow("Build " + pending.ShortID() + " is still in progress, it and the newer builds will be exported on the next run")
+		}
 
-		envs, _ := m.ListBuilds(project, env, cfg.Token)
-		view.PrintEnvHistory(env, envs)
+		if len(builds) == 0 {
+			if pending == nil {
+				color.Green("The history of '" + project + " / " + env + "' on " + dir + " is up to date")
+			}
+			return nil
+		}
+
+		color.Green("Exported " + strconv.Itoa(len(builds)) + " builds of '" + project + " / " + env + "' to " + dir)
 		return nil
 	},
 }
@@ -252,20 +748,20 @@ var ResetEnv = cli.Command{
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if len(c.Args()) < 1 {
-			h.PrintError("You should specify the project name")
+			h.PrintUsageError("You should specify the project name")
 		}
 		if len(c.Args()) < 2 {
-			h.PrintError("You should specify the environment name")
+			h.PrintUsageError("You should specify the environment name")
 		}
 		project := c.Args()[0]
 		env := c.Args()[1]
 		err := m.ResetEnv(project, env, cfg.Token)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 		color.Red("You've successfully resetted the environment '" + project + " / " + env + "'")
 
@@ -274,37 +770,67 @@ var ResetEnv = cli.Command{
 }
 
 // RevertEnv command
+// Applies the definition of a previous build as a new build
 var RevertEnv = cli.Command{
 	Name:        "revert",
 	Usage:       h.T("envs.revert.usage"),
 	ArgsUsage:   h.T("envs.revert.args"),
 	Description: h.T("envs.revert.description"),
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		cli.BoolFlag{
 			Name:  "dry",
 			Usage: "print the changes to be applied on an environment intead of applying them",
 		},
-	},
+		cli.BoolFlag{
+			Name:  "yes,y",
+			Usage: "Revert an environment without prompting confirmation.",
+		},
+	}, MonitorFlags...),
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if len(c.Args()) < 3 {
-			h.PrintError("Please specify a project, environment and build ID")
+			h.PrintUsageError("Please specify a project, environment and build reference")
 		}
 		project := c.Args()[0]
 		env := c.Args()[1]
-		buildID := c.Args()[2]
-		dry := c.Bool("dry")
+		ref := c.Args()[2]
 
-		response, err := m.RevertEnv(project, env, buildID, cfg.Token, dry)
+		d, build, err := m.RevertDefinition(cfg.Token, project, env, ref)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
-		if dry == true {
-			fmt.Println(string(response))
+
+		changes, err := m.DryApplyEnv(cfg.Token, d)
+		if err != nil {
+			h.Fail(err)
+		}
+
+		if len(changes) == 0 {
+			color.Green("Environment '" + project + " / " + env + "' is already on the state of build " + build.ShortID() + ". Nothing will be applied")
+			return nil
+		}
+
+		color.Green("Reverting to build " + build.ShortID() + " (" + build.CreatedAt + ") will:")
+		view.PrintEnvChanges(changes)
+
+		if c.Bool("dry") {
+			os.Exit(h.ExitChangesPending)
+		}
+
+		if !c.Bool("yes") {
+			fmt.Print("Do you really want to revert this environment? (Y/n) ")
+			if askForConfirmation() == false {
+				return nil
+			}
+		}
+
+		_, err = m.ApplyEnv(d, cfg.Token, nil, true, false)
+		if err != nil {
+			h.Fail(err)
 		}
 
 		return nil
@@ -323,33 +849,33 @@ var DefinitionEnv = cli.Command{
 		cli.StringFlag{
 			Name:  "build",
 			Value: "",
-			Usage: "Build ID",
+			Usage: "Build reference (index, ID, latest, previous, latest~N or @date)",
 		},
 	},
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if len(c.Args()) < 1 {
-			h.PrintError("You should specify the project name")
+			h.PrintUsageError("You should specify the project name")
 		}
 		if len(c.Args()) < 2 {
-			h.PrintError("You should specify the env name")
+			h.PrintUsageError("You should specify the env name")
 		}
 		project := c.Args()[0]
 		env := c.Args()[1]
 		if c.String("build") != "" {
-			definition, err := m.BuildDefinitionFromIndex(cfg.Token, project, env, c.String("build"))
+			definition, err := m.BuildDefinition(cfg.Token, project, env, c.String("build"))
 			if err != nil {
-				h.PrintError(err.Error())
+				h.Fail(err)
 			}
 			fmt.Println(string(definition))
 		} else {
 			definition, err := m.LatestBuildDefinition(cfg.Token, project, env)
 			if err != nil {
-				h.PrintError(err.Error())
+				h.Fail(err)
 			}
 
 			fmt.Println(string(definition))
@@ -369,7 +895,11 @@ var InfoEnv = cli.Command{
 		cli.StringFlag{
 			Name:  "build",
 			Value: "",
-			Usage: "Build ID",
+			Usage: "Build reference (index, ID, latest, previous, latest~N or @date)",
+		},
+		cli.StringSliceFlag{
+			Name:  "columns",
+			Usage: "Columns to show for a component type, as type=column,column",
 		},
 	},
 	Action: func(c *cli.Context) error {
@@ -378,14 +908,14 @@ var InfoEnv = cli.Command{
 
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if len(c.Args()) == 0 {
-			h.PrintError("You should specify an existing project name")
+			h.PrintUsageError("You should specify an existing project name")
 		}
 		if len(c.Args()) == 1 {
-			h.PrintError("You should specify an existing env name")
+			h.PrintUsageError("You should specify an existing env name")
 		}
 
 		project := c.Args()[0]
@@ -398,13 +928,34 @@ var InfoEnv = cli.Command{
 		}
 
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
+		}
+
+		columns, err := parseColumns(c.StringSlice("columns"))
+		if err != nil {
+			h.PrintUsageError(err.Error())
 		}
-		view.PrintEnvInfo(&b)
+
+		view.PrintEnvInfoColumns(&b, columns)
 		return nil
 	},
 }
 
+// parseColumns : parses a list of type=column,column column selections
+func parseColumns(specs []string) (map[string][]string, error) {
+	columns := make(map[string][]string)
+
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.New("Invalid columns '" + spec + "', columns should be specified as type=column,column")
+		}
+		columns[parts[0]] = strings.Split(parts[1], ",")
+	}
+
+	return columns, nil
+}
+
 // DiffEnv : Shows detailed information of an env by its name
 var DiffEnv = cli.Command{
 	Name:        "diff",
@@ -417,11 +968,11 @@ var DiffEnv = cli.Command{
 
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if len(c.Args()) < 4 {
-			h.PrintError("You should specify the project and env names and two build ids to compare them")
+			h.PrintUsageError("You should specify the project and env names and two build references to compare them")
 		}
 
 		project := c.Args()[0]
@@ -431,11 +982,11 @@ var DiffEnv = cli.Command{
 
 		build1, err := m.BuildStatus(cfg.Token, project, env, b1)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 		build2, err := m.BuildStatus(cfg.Token, project, env, b2)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
 		view.PrintEnvDiff(build1, build2)
@@ -450,7 +1001,7 @@ var ImportEnv = cli.Command{
 	Usage:       h.T("envs.import.usage"),
 	ArgsUsage:   h.T("envs.import.args"),
 	Description: h.T("envs.import.description"),
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		cli.StringFlag{
 			Name:  "project",
 			Value: "",
@@ -461,21 +1012,25 @@ var ImportEnv = cli.Command{
 			Value: "",
 			Usage: "Import filters comma delimited list",
 		},
-	},
+		cli.StringFlag{
+			Name:  "write",
+			Usage: "Write the definition of the imported environment to a file",
+		},
+	}, append(ExportFlags, MonitorFlags...)...),
 	Action: func(c *cli.Context) error {
 		var err error
 		var filters []string
 
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if len(c.Args()) == 0 {
-			h.PrintError("You should specify an existing project name")
+			h.PrintUsageError("You should specify an existing project name")
 		}
 		if len(c.Args()) == 1 {
-			h.PrintError("You should specify a valid environment name")
+			h.PrintUsageError("You should specify a valid environment name")
 		}
 
 		if c.String("filters") != "" {
@@ -484,15 +1039,623 @@ var ImportEnv = cli.Command{
 
 		project := c.Args()[0]
 		name := c.Args()[1]
+
+		file := c.String("write")
+		if file != "" {
+			checkExportFile(c, file)
+		}
+
 		_, err = m.Import(cfg.Token, name, project, filters)
 
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
+		}
+
+		if file != "" {
+			d, err := m.ExportDefinition(cfg.Token, project, name)
+			if err != nil {
+				h.Fail(err)
+			}
+			writeDefinition(c, d, file)
+		}
+		return nil
+	},
+}
+
+// ExportFlags are the flags of the commands writing definitions
+var ExportFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "overwrite",
+		Usage: "Overwrite the definition file if it exists",
+	},
+	cli.IntFlag{
+		Name:  "blob-size",
+		Value: 512,
+		Usage: "Values longer than this size are written to files referenced with @{file}",
+	},
+}
+
+// ExportEnv : Writes a definition from the latest build of an environment
+var ExportEnv = cli.Command{
+	Name:        "export",
+	Usage:       h.T("envs.export.usage"),
+	ArgsUsage:   h.T("envs.export.args"),
+	Description: h.T("envs.export.description"),
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "write",
+			Usage: "Write the definition to a file instead of printing it",
+		},
+	}, ExportFlags...),
+	Action: func(c *cli.Context) error {
+		m, cfg := setup(c)
+		if cfg.Token == "" {
+			h.Fail(h.ErrNotLoggedIn)
+		}
+
+		if len(c.Args()) == 0 {
+			h.PrintUsageError("You should specify an existing project name")
+		}
+		if len(c.Args()) == 1 {
+			h.PrintUsageError("You should specify an existing environment name")
+		}
+
+		project := c.Args()[0]
+		env := c.Args()[1]
+
+		file := c.String("write")
+		if file != "" {
+			checkExportFile(c, file)
+		}
+
+		d, err := m.ExportDefinition(cfg.Token, project, env)
+		if err != nil {
+			h.Fail(err)
+		}
+
+		if file == "" {
+			data, err := d.Save()
+			if err != nil {
+				h.Fail(err)
+			}
+			fmt.Print(string(data))
+			return nil
+		}
+
+		writeDefinition(c, d, file)
+		return nil
+	},
+}
+
+// checkExportFile : fails when a definition would overwrite a file, unless
+// told otherwise
+func checkExportFile(c *cli.Context, file string) {
+	if _, err := os.Stat(file); err == nil && !c.Bool("overwrite") {
+		h.Fail(h.NewError(h.ExitConflict, "The file "+file+" already exists, use --overwrite to replace it"))
+	}
+}
+
+// writeDefinition : writes a definition to a file, moving its large values
+// to files next to it, which can't overwrite files either
+func writeDefinition(c *cli.Context, d model.Definition, file string) {
+	blobs := d.ExtractBlobs(filepath.Dir(file), c.Int("blob-size"))
+	for _, b := range blobs {
+		checkExportFile(c, b.Path)
+	}
+	for _, b := range blobs {
+		if err := ioutil.WriteFile(b.Path, []byte(b.Content), 0644); err != nil {
+			h.Fail(errors.New("Can't write file " + b.Path))
+		}
+	}
+
+	data, err := d.Save()
+	if err != nil {
+		h.Fail(err)
+	}
+	if err := ioutil.WriteFile(file, data, 0644); err != nil {
+		h.Fail(errors.New("Can't write file " + file))
+	}
+
+	color.Green("Definition written to " + file)
+	for _, b := range blobs {
+		fmt.Println("  " + b.Path)
+	}
+}
+
+// GraphEnv : Prints the components graph of a definition or an environment
+var GraphEnv = cli.Command{
+	Name:        "graph",
+	Usage:       h.T("envs.graph.usage"),
+	ArgsUsage:   h.T("envs.graph.args"),
+	Description: h.T("envs.graph.description"),
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "format",
+			Value: view.GRAPHDOT,
+			Usage: "Graph format: " + strings.Join(view.GraphFormats, ", "),
+		},
+		cli.BoolFlag{
+			Name:  "status",
+			Usage: "Color the components by their status on the latest build",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		var m *manager.Manager
+		var cfg *model.Config
+		var d model.Definition
+		var payload []byte
+		var err error
+
+		if !containsString(view.GraphFormats, c.String("format")) {
+			h.PrintUsageError("Invalid graph format '" + c.String("format") + "', valid formats are " + strings.Join(view.GraphFormats, ", "))
+		}
+
+		remote := len(c.Args()) == 2
+		if remote || c.Bool("status") {
+			m, cfg = setup(c)
+			if cfg.Token == "" {
+				h.Fail(h.ErrNotLoggedIn)
+			}
+		}
+
+		if remote {
+			payload, err = m.LatestBuildDefinition(cfg.Token, c.Args()[0], c.Args()[1])
+		} else {
+			payload, err = readGraphDefinition(c)
+		}
+		if err != nil {
+			h.Fail(err)
+		}
+		if err = d.Load(payload); err != nil {
+			h.Fail(errors.New("Could not process definition yaml"))
+		}
+
+		g := d.Graph()
+		if c.Bool("status") {
+			b, err := m.LatestBuildStatus(cfg.Token, d.Project, d.Name)
+			if err != nil {
+				h.Fail(err)
+			}
+			g.SetStatus(&b)
+		}
+
+		return view.PrintEnvGraph(d.Project+"/"+d.Name, g, c.String("format"))
+	},
+}
+
+// readGraphDefinition : reads the definition file given as argument, or
+// ernest.yml by default
+func readGraphDefinition(c *cli.Context) ([]byte, error) {
+	file := "ernest.yml"
+	if len(c.Args()) == 1 {
+		file = c.Args()[0]
+	}
+
+	payload, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, h.NewError(h.ExitUsage, "You should specify a valid template path or store an ernest.yml on the current folder")
+	}
+	return payload, nil
+}
+
+// DriftEnv : Compares an environment with the live state of its components
+var DriftEnv = cli.Command{
+	Name:        "drift",
+	Usage:       h.T("envs.drift.usage"),
+	ArgsUsage:   h.T("envs.drift.args"),
+	Description: h.T("envs.drift.description"),
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "filters",
+			Value: "",
+			Usage: "Import filters comma delimited list, the environment name by default",
+		},
+		IdleTimeoutFlag,
+	}, ProgressFlags...),
+	Action: func(c *cli.Context) error {
+		var filters []string
+
+		m, cfg := setup(c)
+		if cfg.Token == "" {
+			h.Fail(h.ErrNotLoggedIn)
+		}
+
+		if len(c.Args()) == 0 {
+			h.PrintUsageError("You should specify an existing project name")
+		}
+		if len(c.Args()) == 1 {
+			h.PrintUsageError("You should specify an existing environment name")
+		}
+
+		if c.String("filters") != "" {
+			filters = strings.Split(c.String("filters"), ",")
+		}
+
+		project := c.Args()[0]
+		env := c.Args()[1]
+
+		drift, err := m.Drift(cfg.Token, project, env, filters)
+		if err != nil {
+			h.Fail(err)
+		}
+
+		view.PrintEnvDrift(project+"/"+env, drift)
+		if !drift.Empty() {
+			os.Exit(h.ExitChangesPending)
+		}
+
+		return nil
+	},
+}
+
+// OutputsEnv : Shows the values of the components of an environment
+var OutputsEnv = cli.Command{
+	Name:        "outputs",
+	Usage:       h.T("envs.outputs.usage"),
+	ArgsUsage:   h.T("envs.outputs.args"),
+	Description: h.T("envs.outputs.description"),
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "build",
+			Value: "",
+			Usage: "Build reference (index, ID, latest, previous, latest~N or @date)",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Value: view.OUTPUTSJSON,
+			Usage: "Outputs format (" + strings.Join(view.OutputsFormats, " | ") + ")",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		m, cfg := setup(c)
+		if cfg.Token == "" {
+			h.Fail(h.ErrNotLoggedIn)
+		}
+
+		if len(c.Args()) == 0 {
+			h.PrintUsageError("You should specify an existing project name")
+		}
+		if len(c.Args()) == 1 {
+			h.PrintUsageError("You should specify an existing env name")
+		}
+		if !containsString(view.OutputsFormats, c.String("format")) {
+			h.PrintUsageError("Invalid outputs format '" + c.String("format") + "', valid formats are " + strings.Join(view.OutputsFormats, ", "))
+		}
+
+		project := c.Args()[0]
+		env := c.Args()[1]
+
+		b, err := m.BuildStatus(cfg.Token, project, env, c.String("build"))
+		if err != nil {
+			h.Fail(err)
+		}
+
+		outputs := b.Outputs()
+
+		if len(c.Args()) > 2 {
+			key := c.Args()[2]
+			value, ok := outputs[key]
+			if !ok {
+				h.Fail(h.NewError(h.ExitNotFound, "Output '"+key+"' not found on environment '"+project+" / "+env+"'"))
+			}
+			fmt.Println(value)
+			return nil
+		}
+
+		if err := view.PrintEnvOutputs(outputs, c.String("format")); err != nil {
+			h.Fail(err)
+		}
+
+		return nil
+	},
+}
+
+// InventoryEnv : Generates an inventory of the hosts of an environment
+var InventoryEnv = cli.Command{
+	Name:        "inventory",
+	Usage:       h.T("envs.inventory.usage"),
+	ArgsUsage:   h.T("envs.inventory.args"),
+	Description: h.T("envs.inventory.description"),
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "build",
+			Value: "",
+			Usage: "Build reference (index, ID, latest, previous, latest~N or @date)",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Value: view.INVENTORYINI,
+			Usage: "Inventory format (" + strings.Join(view.InventoryFormats, " | ") + ")",
+		},
+		cli.BoolFlag{
+			Name:  "private",
+			Usage: "Use the private ip of the hosts instead of the public one",
+		},
+		cli.StringFlag{
+			Name:  "user",
+			Value: "",
+			Usage: "User to connect to the hosts with",
+		},
+		cli.StringFlag{
+			Name:  "identity-file",
+			Value: "",
+			Usage: "Private key to connect to the hosts with",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		m, cfg := setup(c)
+		if cfg.Token == "" {
+			h.Fail(h.ErrNotLoggedIn)
+		}
+
+		if len(c.Args()) == 0 {
+			h.PrintUsageError("You should specify an existing project name")
+		}
+		if len(c.Args()) == 1 {
+			h.PrintUsageError("You should specify an existing env name")
+		}
+		if !containsString(view.InventoryFormats, c.String("format")) {
+			h.PrintUsageError("Invalid inventory format '" + c.String("format") + "', valid formats are " + strings.Join(view.InventoryFormats, ", "))
+		}
+
+		b, err := m.BuildStatus(cfg.Token, c.Args()[0], c.Args()[1], c.String("build"))
+		if err != nil {
+			h.Fail(err)
+		}
+
+		err = view.PrintEnvInventory(b.Hosts(), view.InventoryOptions{
+			Format:   c.String("format"),
+			Private:  c.Bool("private"),
+			User:     c.String("user"),
+			Identity: c.String("identity-file"),
+		})
+		if err != nil {
+			h.Fail(err)
+		}
+
+		return nil
+	},
+}
+
+// GCEnv : Destroys the environments not built for a while
+var GCEnv = cli.Command{
+	Name:        "gc",
+	Usage:       h.T("envs.gc.usage"),
+	ArgsUsage:   h.T("envs.gc.args"),
+	Description: h.T("envs.gc.description"),
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "older-than",
+			Usage: "Minimum time since the latest build of an environment was created, like 7d or 36h",
+		},
+		cli.StringFlag{
+			Name:  "idle",
+			Usage: "Minimum time since the latest build of an environment finished, like 2d",
+		},
+		cli.IntFlag{
+			Name:  "concurrency",
+			Value: 2,
+			Usage: "Maximum number of environments destroyed at the same time",
+		},
+		cli.BoolFlag{
+			Name:  "dry",
+			Usage: "List the environments to destroy without destroying them",
+		},
+		cli.BoolFlag{
+			Name:  "yes,y",
+			Usage: "Destroy the environments without prompting confirmation",
+		},
+		cli.BoolFlag{
+			Name:  "i-know",
+			Usage: "Also destroy the protected environments",
+		},
+		IdleTimeoutFlag,
+		ReportFlag,
+	},
+	Action: func(c *cli.Context) error {
+		m, cfg := setup(c)
+		if cfg.Token == "" {
+			h.Fail(h.ErrNotLoggedIn)
+		}
+
+		pattern := "*/*"
+		if len(c.Args()) > 0 {
+			pattern = c.Args()[0]
+		}
+
+		if c.String("older-than") == "" {
+			h.PrintUsageError("You should specify the minimum age of the environments to destroy with --older-than")
+		}
+		olderThan, err := parseAge(c.String("older-than"))
+		if err != nil {
+			h.PrintUsageError(err.Error())
+		}
+		var idle time.Duration
+		if c.String("idle") != "" {
+			if idle, err = parseAge(c.String("idle")); err != nil {
+				h.PrintUsageError(err.Error())
+			}
+		}
+
+		envs, err := m.StaleEnvs(cfg.Token, manager.GCOptions{
+			Pattern:   pattern,
+			OlderThan: olderThan,
+			Idle:      idle,
+		}, time.Now())
+		if err != nil {
+			h.Fail(err)
 		}
+
+		envs = unprotectedEnvs(cfg, envs, c.Bool("i-know"))
+
+		if len(envs) == 0 {
+			color.Green("There are no environments to destroy")
+			return nil
+		}
+
+		color.Yellow("The following environments will be destroyed:")
+		view.PrintStaleEnvs(envs)
+
+		if c.Bool("dry") {
+			os.Exit(h.ExitChangesPending)
+		}
+
+		if !c.Bool("yes") {
+			fmt.Print("Do you really want to destroy these " + strconv.Itoa(len(envs)) + " environments? (Y/n) ")
+			if askForConfirmation() == false {
+				return nil
+			}
+		}
+
+		m.CollectEnvs(cfg.Token, envs, c.Int("concurrency"))
+		view.PrintGCReport(envs)
+
+		for _, e := range envs {
+			if e.Err != nil {
+				os.Exit(h.ExitCode(e.Err))
+			}
+		}
+
 		return nil
 	},
 }
 
+// unprotectedEnvs : skips the protected environments, unless told otherwise
+func unprotectedEnvs(cfg *model.Config, envs []model.StaleEnv, iknow bool) []model.StaleEnv {
+	if iknow {
+		return envs
+	}
+
+	var unprotected []model.StaleEnv
+	for _, e := range envs {
+		if cfg.IsProtected(e.Project, e.Name) {
+			color.Yellow("Skipping protected environment " + e.FullName())
+			continue
+		}
+		unprotected = append(unprotected, e)
+	}
+
+	return unprotected
+}
+
+// parseAge : parses a duration, also accepting a number of days like 7d
+func parseAge(value string) (time.Duration, error) {
+	var days time.Duration
+
+	if i := strings.Index(value, "d"); i > 0 {
+		n, err := strconv.Atoi(value[:i])
+		if err != nil {
+			return 0, errors.New("Invalid duration '" + value + "', expected a duration like 7d or 36h")
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		value = value[i+1:]
+	}
+
+	if value == "" {
+		return days, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.New("Invalid duration '" + value + "', expected a duration like 7d or 36h")
+	}
+
+	return days + d, nil
+}
+
+// TimingsEnv : Shows how long each component of a build took
+var TimingsEnv = cli.Command{
+	Name:        "timings",
+	Usage:       h.T("envs.timings.usage"),
+	ArgsUsage:   h.T("envs.timings.args"),
+	Description: h.T("envs.timings.description"),
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "build",
+			Value: "",
+			Usage: "Build reference (index, ID, latest, previous, latest~N or @date)",
+		},
+		cli.StringFlag{
+			Name:  "recording",
+			Value: "",
+			Usage: "Compute the timings from a build recorded with --record",
+		},
+		IdleTimeoutFlag,
+	},
+	Action: func(c *cli.Context) error {
+		var events []h.RecordedEvent
+		var err error
+
+		if c.String("recording") != "" {
+			events, err = h.ReadRecording(c.String("recording"))
+			if err != nil {
+				h.Fail(err)
+			}
+		} else {
+			events = followBuildEvents(c)
+		}
+
+		timings, err := h.BuildTimingsFromEvents(events)
+		if err != nil {
+			h.Fail(err)
+		}
+
+		view.PrintEnvTimings(timings)
+		return nil
+	},
+}
+
+// followBuildEvents : follows an in progress build until it finishes,
+// returning the events it received
+func followBuildEvents(c *cli.Context) []h.RecordedEvent {
+	m, cfg := setup(c)
+	if cfg.Token == "" {
+		h.Fail(h.ErrNotLoggedIn)
+	}
+
+	if len(c.Args()) < 1 {
+		h.PrintUsageError("You should specify the project name")
+	}
+	if len(c.Args()) < 2 {
+		h.PrintUsageError("You should specify the env name")
+	}
+	project := c.Args()[0]
+	env := c.Args()[1]
+
+	build, err := m.BuildStatus(cfg.Token, project, env, c.String("build"))
+	if err != nil {
+		h.Fail(err)
+	}
+
+	if build.Status != "in_progress" {
+		h.Fail(h.NewError(h.ExitUsage, "Build "+build.ShortID()+" has already finished. Its timings can be computed from a recording made with --record, using the --recording option"))
+	}
+
+	f, err := ioutil.TempFile("", "ernest-timings")
+	if err != nil {
+		h.Fail(err)
+	}
+	_ = f.Close()
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+	m.Monitor.Record = f.Name()
+
+	// the progress goes to stderr, leaving stdout to the timings, and
+	// failed builds are timed as well
+	m.Monitor.Output = os.Stderr
+	if err = m.MonitorBuild(cfg.Token, project, env, build.ID); err != nil {
+		color.New(color.FgRed).Fprintln(os.Stderr, err.Error())
+	}
+
+	events, err := h.ReadRecording(m.Monitor.Record)
+	if err != nil {
+		h.Fail(err)
+	}
+
+	return events
+}
+
 func getEnvUUID(output []byte) (string, error) {
 	var env struct {
 		ID string `json:"id"`
@@ -530,6 +1693,8 @@ var CmdEnv = cli.Command{
 		CreateEnv,
 		UpdateEnv,
 		ApplyEnv,
+		WatchEnv,
+		InitEnv,
 		DestroyEnv,
 		HistoryEnv,
 		ResetEnv,
@@ -539,5 +1704,14 @@ var CmdEnv = cli.Command{
 		MonitorEnv,
 		DiffEnv,
 		ImportEnv,
+		ExportEnv,
+		DriftEnv,
+		GraphEnv,
+		TimingsEnv,
+		OutputsEnv,
+		InventoryEnv,
+		GCEnv,
+		ProtectEnv,
+		UnprotectEnv,
 	},
 }
@@ -29,7 +29,7 @@ var CmdLog = cli.Command{
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		uu, _ := uuid.NewV4()
@@ -39,7 +39,7 @@ var CmdLog = cli.Command{
 		}
 
 		if err := m.SetLogger(cfg.Token, logger); err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
 		if c.Bool("raw") {
@@ -50,7 +50,7 @@ var CmdLog = cli.Command{
 
 		defer func() {
 			if err := m.DelLogger(cfg.Token, logger); err != nil {
-				h.PrintError("Ernest wasn't able to reset sse logger")
+				h.Fail(h.NewError(h.ExitError, "Ernest wasn't able to reset sse logger"))
 			}
 		}()
 
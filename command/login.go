@@ -71,13 +71,13 @@ var Login = cli.Command{
 
 		token, err := m.Login(username, password)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 		cfg.Token = token
 		cfg.User = username
 		err = model.SaveConfig(cfg)
 		if err != nil {
-			h.PrintError("Can't write config file")
+			h.Fail(h.NewError(h.ExitError, "Can't write config file"))
 		}
 		color.Green("Welcome back " + username)
 		return nil
@@ -23,7 +23,7 @@ var Logout = cli.Command{
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're already logged out")
+			h.Fail(h.NewError(h.ExitAuth, "You're already logged out"))
 		}
 		if m == nil {
 			os.Exit(1)
@@ -32,7 +32,7 @@ var Logout = cli.Command{
 		cfg.User = ""
 		err := model.SaveConfig(cfg)
 		if err != nil {
-			h.PrintError("Can't write config file")
+			h.Fail(h.NewError(h.ExitError, "Can't write config file"))
 		}
 		color.Green("Bye.")
 		return nil
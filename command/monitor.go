@@ -5,6 +5,9 @@
 package command
 
 import (
+	"strings"
+	"time"
+
 	"github.com/fatih/color"
 	"github.com/urfave/cli"
 
@@ -17,6 +20,66 @@ type NullWriter int
 // Write sends to nowhere the log messages
 func (NullWriter) Write([]byte) (int, error) { return 0, nil }
 
+// ProgressFlags are the flags of the commands rendering a build progress
+var ProgressFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "verbose",
+		Usage: "list the progress of every component of the build, with their running times",
+	},
+	cli.StringFlag{
+		Name:  "progress",
+		Usage: "progress output format (" + strings.Join(h.ProgressFormats, " | ") + "), defaults to live on terminals and plain elsewhere",
+	},
+}
+
+// ReportFlag is the flag of the commands reporting a build outcome as test results
+var ReportFlag = cli.StringSliceFlag{
+	Name:  "report",
+	Usage: "write the build outcome as test results, as format=file where format is one of " + strings.Join(h.ReportFormats, " | "),
+}
+
+// IdleTimeoutFlag is the flag of the commands falling back to poll a build status
+var IdleTimeoutFlag = cli.DurationFlag{
+	Name:  "idle-timeout",
+	Value: time.Minute,
+	Usage: "time without build events after which the build status is polled instead",
+}
+
+// MonitorFlags are the flags of the commands following a build progress
+var MonitorFlags = append([]cli.Flag{
+	IdleTimeoutFlag,
+	cli.StringFlag{
+		Name:  "record",
+		Usage: "record the build events to a file that can be replayed with 'ernest replay'",
+	},
+	ReportFlag,
+}, ProgressFlags...)
+
+// monitorOptions : gets the build monitoring settings from the command flags
+func monitorOptions(c *cli.Context) h.MonitorOptions {
+	progress := c.String("progress")
+	if progress != "" && !containsString(h.ProgressFormats, progress) {
+		h.PrintUsageError("Invalid progress format '" + progress + "', valid formats are " + strings.Join(h.ProgressFormats, ", "))
+	}
+
+	opts := h.MonitorOptions{
+		IdleTimeout: c.Duration("idle-timeout"),
+		Verbose:     c.Bool("verbose"),
+		Progress:    progress,
+		Record:      c.String("record"),
+	}
+
+	if len(c.StringSlice("report")) > 0 {
+		report, err := h.NewReport(c.StringSlice("report"))
+		if err != nil {
+			h.Fail(err)
+		}
+		opts.Report = report
+	}
+
+	return opts
+}
+
 // MonitorEnv command
 // Monitorizes an environment and shows the actions being performed on it
 var MonitorEnv = cli.Command{
@@ -25,18 +88,18 @@ var MonitorEnv = cli.Command{
 	Usage:       h.T("monitor.usage"),
 	ArgsUsage:   h.T("monitor.args"),
 	Description: h.T("monitor.description"),
+	Flags:       MonitorFlags,
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			color.Red("You're not allowed to perform this action, please log in")
-			return nil
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if len(c.Args()) == 0 {
-			h.PrintError("You should specify an existing project name")
+			h.PrintUsageError("You should specify an existing project name")
 		}
 		if len(c.Args()) == 1 {
-			h.PrintError("You should specify an existing env name")
+			h.PrintUsageError("You should specify an existing env name")
 		}
 
 		project := c.Args()[0]
@@ -44,12 +107,12 @@ var MonitorEnv = cli.Command{
 
 		id, err := m.LatestBuildID(cfg.Token, project, env)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
-		build, err := m.BuildStatus(cfg.Token, project, env, id)
+		build, err := m.BuildStatusByID(cfg.Token, project, env, id)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
 		if build.Status == "done" {
@@ -58,6 +121,6 @@ var MonitorEnv = cli.Command{
 			return nil
 		}
 
-		return h.Monitorize(cfg.URL, "/events", cfg.Token, build.ID)
+		return m.MonitorBuild(cfg.Token, project, env, build.ID)
 	},
 }
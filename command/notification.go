@@ -21,11 +21,11 @@ var ListNotifications = cli.Command{
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 		notifications, err := m.ListNotifications(cfg.Token)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
 		view.PrintNotificationList(notifications)
@@ -42,14 +42,14 @@ var DeleteNotification = cli.Command{
 	Description: h.T("notification.delete.description"),
 	Action: func(c *cli.Context) error {
 		if len(c.Args()) < 1 {
-			h.PrintError("You should specify a valid name")
+			h.PrintUsageError("You should specify a valid name")
 		}
 
 		name := c.Args()[0]
 		m, cfg := setup(c)
 		err := m.DeleteNotification(cfg.Token, name)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 		color.Green("Notify " + name + " successfully delete")
 		return nil
@@ -64,11 +64,11 @@ var UpdateNotification = cli.Command{
 	Description: h.T("notification.update.description"),
 	Action: func(c *cli.Context) error {
 		if len(c.Args()) < 1 {
-			h.PrintError("You should specify a valid name")
+			h.PrintUsageError("You should specify a valid name")
 		}
 
 		if len(c.Args()) < 2 {
-			h.PrintError("You should specify a notify config options")
+			h.PrintUsageError("You should specify a notify config options")
 		}
 
 		name := c.Args()[0]
@@ -76,7 +76,7 @@ var UpdateNotification = cli.Command{
 		m, cfg := setup(c)
 		err := m.UpdateNotification(cfg.Token, name, notifyConfig)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 		color.Green("Notify " + name + " successfully updated")
 		return nil
@@ -91,13 +91,13 @@ var AddServiceToNotification = cli.Command{
 	Description: h.T("notification.service.add.description"),
 	Action: func(c *cli.Context) error {
 		if len(c.Args()) < 1 {
-			h.PrintError("You should specify a valid project name")
+			h.PrintUsageError("You should specify a valid project name")
 		}
 		if len(c.Args()) < 2 {
-			h.PrintError("You should specify a valid environment name")
+			h.PrintUsageError("You should specify a valid environment name")
 		}
 		if len(c.Args()) < 3 {
-			h.PrintError("You should specify a valid notify name")
+			h.PrintUsageError("You should specify a valid notify name")
 		}
 
 		service := c.Args()[0] + "/" + c.Args()[1]
@@ -105,7 +105,7 @@ var AddServiceToNotification = cli.Command{
 		m, cfg := setup(c)
 		err := m.AddServiceToNotification(cfg.Token, service, notify, false)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 		color.Green("Environment " + service + " successfully attached to " + notify + " notify")
 		return nil
@@ -120,10 +120,10 @@ var RmServiceToNotification = cli.Command{
 	Description: h.T("notification.service.rm.description"),
 	Action: func(c *cli.Context) error {
 		if len(c.Args()) < 1 {
-			h.PrintError("You should specify a valid environment name")
+			h.PrintUsageError("You should specify a valid environment name")
 		}
 		if len(c.Args()) < 2 {
-			h.PrintError("You should specify a valid notify name")
+			h.PrintUsageError("You should specify a valid notify name")
 		}
 
 		service := c.Args()[0]
@@ -131,7 +131,7 @@ var RmServiceToNotification = cli.Command{
 		m, cfg := setup(c)
 		err := m.AddServiceToNotification(cfg.Token, service, notify, true)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 		color.Green("Environment " + service + " successfully removed from " + notify + " notify")
 		return nil
@@ -146,14 +146,14 @@ var CreateNotification = cli.Command{
 	Description: h.T("notification.create.description"),
 	Action: func(c *cli.Context) error {
 		if len(c.Args()) < 1 {
-			h.PrintError("You should specify a valid name")
+			h.PrintUsageError("You should specify a valid name")
 		}
 		if len(c.Args()) < 2 {
-			h.PrintError("You should specify a notify type")
+			h.PrintUsageError("You should specify a notify type")
 		}
 
 		if len(c.Args()) < 3 {
-			h.PrintError("You should specify a notify config options")
+			h.PrintUsageError("You should specify a notify config options")
 		}
 
 		name := c.Args()[0]
@@ -162,7 +162,7 @@ var CreateNotification = cli.Command{
 		m, cfg := setup(c)
 		_, err := m.CreateNotification(cfg.Token, name, notifyType, notifyConfig)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 		color.Green("Notify " + name + " successfully created")
 		return nil
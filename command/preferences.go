@@ -22,11 +22,11 @@ var ListLoggers = cli.Command{
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 		loggers, err := m.ListLoggers(cfg.Token)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
 		view.PrintLoggerList(loggers)
@@ -70,11 +70,11 @@ var SetLogger = cli.Command{
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if len(c.Args()) < 1 {
-			h.PrintError("You should specify the logger type (basic | logstash)")
+			h.PrintUsageError("You should specify the logger type (basic | logstash)")
 		}
 
 		logger := model.Logger{
@@ -88,34 +88,33 @@ var SetLogger = cli.Command{
 		}
 		if logger.Type == "basic" {
 			if logger.Logfile == "" {
-				h.PrintError("You should specify a logfile with --logfile flag")
+				h.PrintUsageError("You should specify a logfile with --logfile flag")
 			}
 		} else if logger.Type == "logstash" {
 			if logger.Hostname == "" {
-				h.PrintError("You should specify a logstash hostname  with --hostname flag")
+				h.PrintUsageError("You should specify a logstash hostname  with --hostname flag")
 			}
 			if logger.Port == 0 {
-				h.PrintError("You should specify a logstash port with --port flag")
+				h.PrintUsageError("You should specify a logstash port with --port flag")
 			}
 			if logger.Timeout == 0 {
-				h.PrintError("You should specify a logstash timeout with --timeout flag")
+				h.PrintUsageError("You should specify a logstash timeout with --timeout flag")
 			}
 
 		} else if logger.Type == "rollbar" {
 			if logger.Token == "" {
-				h.PrintError("You should specify a rollbar token with --token flag")
+				h.PrintUsageError("You should specify a rollbar token with --token flag")
 			}
 			if logger.Environment == "" {
 				logger.Environment = "development"
 			}
 		} else {
-			color.Red("Invalid type, valid types are basic and logstash")
-			return nil
+			h.PrintUsageError("Invalid type, valid types are basic and logstash")
 		}
 
 		err := m.SetLogger(cfg.Token, logger)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
 		color.Green("Logger successfully set up")
@@ -132,7 +131,7 @@ var DelLogger = cli.Command{
 	Description: h.T("logger.del.description"),
 	Action: func(c *cli.Context) error {
 		if len(c.Args()) < 1 {
-			h.PrintError("You should specify the logger type (basic | logstash | rollbar)")
+			h.PrintUsageError("You should specify the logger type (basic | logstash | rollbar)")
 		}
 
 		logger := model.Logger{
@@ -141,12 +140,12 @@ var DelLogger = cli.Command{
 
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		err := m.DelLogger(cfg.Token, logger)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
 		color.Green("Logger successfully deleted")
@@ -25,11 +25,11 @@ var ListProjects = cli.Command{
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 		projects, err := m.ListProjects(cfg.Token)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
 		view.PrintProjectList(projects)
@@ -47,15 +47,15 @@ var InfoProject = cli.Command{
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 		if len(c.Args()) == 0 {
-			h.PrintError("You should specify the project name")
+			h.PrintUsageError("You should specify the project name")
 		}
 		project := c.Args()[0]
 		p, err := m.InfoProject(cfg.Token, project)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
 		view.PrintProjectInfo(p)
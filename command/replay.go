@@ -0,0 +1,46 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package command
+
+import (
+	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/urfave/cli"
+)
+
+// CmdReplay command
+// Renders a build recorded with --record as it was received
+var CmdReplay = cli.Command{
+	Name:        "replay",
+	Usage:       h.T("replay.usage"),
+	ArgsUsage:   h.T("replay.args"),
+	Description: h.T("replay.description"),
+	Flags: append([]cli.Flag{
+		cli.Float64Flag{
+			Name:  "speed",
+			Value: 1,
+			Usage: "replay speed multiplier, 0 replays the build without waiting",
+		},
+		ReportFlag,
+	}, ProgressFlags...),
+	Action: func(c *cli.Context) error {
+		if len(c.Args()) < 1 {
+			h.PrintUsageError("You should specify the recording file")
+		}
+		if c.Float64("speed") < 0 {
+			h.PrintUsageError("The replay speed can't be negative")
+		}
+
+		events, err := h.ReadRecording(c.Args()[0])
+		if err != nil {
+			h.Fail(err)
+		}
+
+		if err := h.Replay(events, c.Float64("speed"), monitorOptions(c)); err != nil {
+			h.Fail(err)
+		}
+
+		return nil
+	},
+}
@@ -45,19 +45,19 @@ var CmdRolesSet = cli.Command{
 		p := c.String("project")
 		e := c.String("environment")
 		if r == "" {
-			h.PrintError("Please provide a role with --role flag")
+			h.PrintUsageError("Please provide a role with --role flag")
 		}
 		if u == "" {
-			h.PrintError("Please provide a user with --user flag")
+			h.PrintUsageError("Please provide a user with --user flag")
 		}
 		if p == "" {
-			h.PrintError("Please provide a project with --project flag")
+			h.PrintUsageError("Please provide a project with --project flag")
 		}
 
 		m, cfg := setup(c)
 		body, err := m.SetRole(cfg.Token, u, p, e, r)
 		if err != nil {
-			h.PrintError(body)
+			h.Fail(h.WithMessage(err, body))
 		}
 		resource := p
 		if e != "" {
@@ -107,19 +107,19 @@ var CmdRolesUnset = cli.Command{
 		p := c.String("project")
 		e := c.String("environment")
 		if r == "" {
-			h.PrintError("Please provide a role with --role flag")
+			h.PrintUsageError("Please provide a role with --role flag")
 		}
 		if u == "" {
-			h.PrintError("Please provide a user with --user flag")
+			h.PrintUsageError("Please provide a user with --user flag")
 		}
 		if p == "" {
-			h.PrintError("Please provide a project with --project flag")
+			h.PrintUsageError("Please provide a project with --project flag")
 		}
 
 		m, cfg := setup(c)
 		body, err := m.UnsetRole(cfg.Token, u, p, e, r)
 		if err != nil {
-			h.PrintError(body)
+			h.Fail(h.WithMessage(err, body))
 			return nil
 		}
 
@@ -23,12 +23,12 @@ var Target = cli.Command{
 	Description: h.T("target.description"),
 	Action: func(c *cli.Context) error {
 		if len(c.Args()) < 1 {
-			h.PrintError("You should specify the target url")
+			h.PrintUsageError("You should specify the target url")
 		}
 		_, cfg := setup(c)
 		cfg.URL = c.Args()[0]
 		if err := persistTarget(cfg); err != nil {
-			h.PrintError("Couldn't write config file ~/.ernest check permissions")
+			h.Fail(h.NewError(h.ExitError, "Couldn't write config file ~/.ernest check permissions"))
 		}
 		color.Green("Target set")
 		return nil
@@ -41,8 +41,7 @@ func persistTarget(cfg *model.Config) error {
 		color.Yellow("Warning! Your are using an insecure target for Ernest")
 	}
 	if u.Scheme != "https" && u.Scheme != "http" {
-		color.Red("You should specify a valid url for the target")
-		return nil
+		h.PrintUsageError("You should specify a valid url for the target")
 	}
 	err := model.SaveConfig(cfg)
 	if err != nil {
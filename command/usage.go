@@ -39,16 +39,16 @@ var CmdUsage = cli.Command{
 
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if body, err = m.GetUsageReport(cfg.Token, c.String("from"), c.String("to")); err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
 		if c.String("output") != "" {
 			if err := ioutil.WriteFile(c.String("output"), []byte(body), 0644); err != nil {
-				h.PrintError(err.Error())
+				h.Fail(err)
 			}
 			color.Green("A file named " + c.String("output") + " has been exported to the current folder")
 		} else {
@@ -33,7 +33,7 @@ var ListUsers = cli.Command{
 		m, cfg := setup(c)
 		users, err := m.ListUsers(cfg.Token)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
 		w := new(tabwriter.Writer)
@@ -70,10 +70,10 @@ var CreateUser = cli.Command{
 	},
 	Action: func(c *cli.Context) error {
 		if len(c.Args()) < 1 {
-			h.PrintError("You should specify an user username and a password")
+			h.PrintUsageError("You should specify an user username and a password")
 		}
 		if len(c.Args()) < 2 {
-			h.PrintError("You should specify the user password")
+			h.PrintUsageError("You should specify the user password")
 		}
 
 		usr := c.Args()[0]
@@ -82,7 +82,7 @@ var CreateUser = cli.Command{
 		m, cfg := setup(c)
 		err := m.CreateUser(cfg.Token, usr, email, usr, pwd)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 		color.Green("User " + usr + " successfully created")
 		return nil
@@ -120,32 +120,32 @@ var PasswordUser = cli.Command{
 
 		session, err := m.GetSession(cfg.Token)
 		if err != nil {
-			h.PrintError("You don’t have permissions to perform this action")
+			h.Fail(h.ErrNoPermission)
 		}
 
 		if !session.IsAdmin && username != "" {
-			h.PrintError("You don’t have permissions to perform this action")
+			h.Fail(h.ErrNoPermission)
 		}
 
 		if session.IsAdmin && username != "" {
 			if password == "" {
-				h.PrintError("Please provide a valid password for the user with `--password`")
+				h.PrintUsageError("Please provide a valid password for the user with `--password`")
 			}
 
 			// Just change the password with the given values for the given user
 			usr, err := m.GetUserByUsername(cfg.Token, username)
 			if err = m.ChangePasswordByAdmin(cfg.Token, usr.ID, usr.Username, usr.GroupID, password); err != nil {
-				h.PrintError(err.Error())
+				h.Fail(err)
 			}
 			color.Green("`" + usr.Username + "` password has been changed")
 		} else {
 			// Ask the user for credentials
 			var users []model.User
 			if users, err = m.ListUsers(cfg.Token); err != nil {
-				h.PrintError("You don’t have permissions to perform this action")
+				h.Fail(h.ErrNoPermission)
 			}
 			if len(users) == 0 {
-				h.PrintError("You don’t have permissions to perform this action")
+				h.Fail(h.ErrNoPermission)
 			}
 
 			var user model.User
@@ -176,12 +176,12 @@ var PasswordUser = cli.Command{
 			}
 
 			if newpassword != rnewpassword {
-				h.PrintError("Aborting... New password and confirmation doesn't match.")
+				h.PrintUsageError("Aborting... New password and confirmation doesn't match.")
 			}
 
 			err = m.ChangePassword(cfg.Token, user.ID, user.Username, user.GroupID, oldpassword, newpassword)
 			if err != nil {
-				h.PrintError(err.Error())
+				h.Fail(err)
 			}
 			color.Green("Your password has been changed")
 		}
@@ -198,7 +198,7 @@ var DisableUser = cli.Command{
 	Description: h.T("user.disable.description"),
 	Action: func(c *cli.Context) error {
 		if len(c.Args()) < 1 {
-			h.PrintError("You should specify an username")
+			h.PrintUsageError("You should specify an username")
 		}
 
 		m, cfg := setup(c)
@@ -206,20 +206,20 @@ var DisableUser = cli.Command{
 
 		session, err := m.GetSession(cfg.Token)
 		if err != nil {
-			h.PrintError("You don’t have permissions to perform this action")
+			h.Fail(h.ErrNoPermission)
 		}
 
 		if !session.IsAdmin {
-			h.PrintError("You don’t have permissions to perform this action")
+			h.Fail(h.ErrNoPermission)
 		}
 
 		user, err := m.GetUserByUsername(cfg.Token, username)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
 		if err = m.ChangePasswordByAdmin(cfg.Token, user.ID, user.Username, user.GroupID, randString(16)); err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
 		color.Green("Account `" + username + "` has been disabled")
@@ -243,12 +243,12 @@ var InfoUser = cli.Command{
 		m, cfg := setup(c)
 		session, err := m.GetSession(cfg.Token)
 		if err != nil {
-			h.PrintError("You don’t have permissions to perform this action")
+			h.Fail(h.ErrNoPermission)
 		}
 
 		username := c.String("user")
 		if username != "" && session.IsAdmin == false {
-			h.PrintError("You don’t have permissions to access '" + username + "' information")
+			h.Fail(h.NewError(h.ExitPermission, "You don’t have permissions to access '"+username+"' information"))
 		}
 		if username == "" {
 			username = cfg.User
@@ -256,7 +256,7 @@ var InfoUser = cli.Command{
 
 		user, err := m.GetUser(cfg.Token, username)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 
 		view.PrintUserInfo(user)
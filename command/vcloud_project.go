@@ -65,11 +65,11 @@ var CreateVcloudProject = cli.Command{
 		var errs []string
 
 		if len(c.Args()) == 0 {
-			h.PrintError("You should specify the project name")
+			h.PrintUsageError("You should specify the project name")
 		}
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		name := c.Args()[0]
@@ -80,7 +80,7 @@ var CreateVcloudProject = cli.Command{
 		if template != "" {
 			var t model.ProjectTemplate
 			if err := getProjectTemplate(template, &t); err != nil {
-				h.PrintError(err.Error())
+				h.Fail(err)
 			}
 			url = t.URL
 			network = t.Network
@@ -133,12 +133,12 @@ var CreateVcloudProject = cli.Command{
 			for _, e := range errs {
 				msgs = append(msgs, "  - "+e)
 			}
-			h.PrintError(strings.Join(msgs, "\n"))
+			h.PrintUsageError(strings.Join(msgs, "\n"))
 		}
 
 		body, err := m.CreateVcloudProject(cfg.Token, name, rtype, username, password, url, network, c.String("vse-url"))
 		if err != nil {
-			h.PrintError(body)
+			h.Fail(h.WithMessage(err, body))
 		} else {
 			color.Green("Project '" + name + "' successfully created ")
 		}
@@ -155,17 +155,17 @@ var DeleteProject = cli.Command{
 	Action: func(c *cli.Context) error {
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 
 		if len(c.Args()) == 0 {
-			h.PrintError("You should specify the project name")
+			h.PrintUsageError("You should specify the project name")
 		}
 		name := c.Args()[0]
 
 		err := m.DeleteProject(cfg.Token, name)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 		color.Green("Project " + name + " successfully removed")
 
@@ -200,10 +200,10 @@ var UpdateVCloudProject = cli.Command{
 		var user, password, org string
 		m, cfg := setup(c)
 		if cfg.Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.Fail(h.ErrNotLoggedIn)
 		}
 		if len(c.Args()) == 0 {
-			h.PrintError("You should specify the project name")
+			h.PrintUsageError("You should specify the project name")
 		}
 		name := c.Args()[0]
 		user = c.String("user")
@@ -211,18 +211,18 @@ var UpdateVCloudProject = cli.Command{
 		org = c.String("org")
 
 		if user == "" {
-			h.PrintError("You should specify user name with '--user' flag")
+			h.PrintUsageError("You should specify user name with '--user' flag")
 		}
 		if password == "" {
-			h.PrintError("You should specify user password with '--password' flag")
+			h.PrintUsageError("You should specify user password with '--password' flag")
 		}
 		if org == "" {
-			h.PrintError("You should specify user org with '--org' flag")
+			h.PrintUsageError("You should specify user org with '--org' flag")
 		}
 
 		err := m.UpdateVCloudProject(cfg.Token, name, user+"@"+org, password)
 		if err != nil {
-			h.PrintError(err.Error())
+			h.Fail(err)
 		}
 		color.Green("Project " + name + " successfully updated")
 
@@ -161,6 +161,27 @@ var _langEnYml = []byte(`en:
 
         Example:
           $ ernest component list my_project ebs --environment=my_env
+  convert:
+    terraform:
+      usage: "Converts an aws terraform configuration to a definition."
+      args: "<terraform_dir>"
+      description: |
+        Translates the resources of the .tf files of a directory to a definition, written
+        to ernest.yml or the file given with --output. The supported resources are
+        aws_vpc, aws_subnet, aws_security_group, aws_nat_gateway, aws_instance,
+        aws_ebs_volume, aws_elb and aws_db_instance, on the terraform 0.11 syntax.
+
+        Resources are named after their terraform names, and references between them,
+        like "${aws_subnet.web.id}", become references by name. Variables are replaced
+        with their defaults, or their values on .tfvars files of the directory.
+
+        Unsupported resources, blocks, attributes and expressions are not converted, and
+        are listed on a report with their file and line, to be reviewed before applying
+        the definition.
+
+        Examples:
+          $ ernest convert terraform --project my_project ./terraform
+          $ ernest convert terraform --project my_project --name my_env --output my_env.yml ./terraform
   docs:
     usage: "Open docs in the default browser."
     args: ""
@@ -204,25 +225,142 @@ var _langEnYml = []byte(`en:
 
         If the file is not provided, ernest.yml will be used by default.
 
+        Use --verbose to follow the progress of every component of the build.
+        The progress is rewritten in place on terminals, and printed as one timestamped
+        line per state transition elsewhere, as on CI logs. Use --progress live|plain to
+        choose it explicitly. Colors are disabled when NO_COLOR is set.
+
+        With --progress json every build event is written as a json line with its
+        timestamp, build_id, subject, component_type, component_name, action, state and
+        error, followed by a final summary record.
+
+        With --report junit=<file> or --report tap=<file> the build outcome is written
+        as test results, the environment being a test suite and every component a test
+        case failing with its error, so CI systems can show it natively. The option can
+        be repeated to write several reports.
+
+        With --dry the command exits with 9 when applying the file would change the
+        environment, and with 0 otherwise.
+
+        Use --git to attach the git commit, branch, remote and whether there were
+        uncommitted changes on the working tree of the file to the build, and --message
+        to attach a message. They are sent on the reserved _metadata section of the
+        definition, shown by 'ernest env history' and 'ernest env info', and left out
+        of 'ernest env diff'.
+
+        Local commands can run around the build on pre-apply, post-apply and
+        on-failure hooks, declared on a top level hooks section of the file, which is
+        never sent to Ernest, or on the hooks of your ~/.ernest config, which run
+        first. A failing pre-apply hook aborts the apply. See the README for the
+        context hooks receive.
+
         Examples:
           $ ernest env apply myenvironment.yml
           $ ernest env apply --dry myenvironment.yml
+          $ ernest env apply --verbose myenvironment.yml
+          $ ernest env apply --report junit=ernest.xml myenvironment.yml
+          $ ernest env apply --git --message "Scale web instances" myenvironment.yml
+    init:
+      usage: "Generates a starter definition for a provider."
+      args: " "
+      description: |
+        Generates a commented ernest.yml for --provider aws, azure or vcloud, with the
+        name and project keys, a network and, with the web-app preset, the security
+        groups, instances and load balancer of a typical web application, plus a
+        user-data.yml cloud-init template the instances reference with
+        @{user-data.yml}, a path relative to the definition. The vpc-only preset
+        generates the network alone.
+
+        Values not given as flags are prompted for when running on a terminal, and take
+        their defaults otherwise: a 10.0.0.0/16 --cidr network range, a 10.0.1.0/24
+        --subnet and 2 instances (--count). --name and --project are always required.
+
+        Files are written to --dir, the current folder by default, and existing files
+        are only replaced with --overwrite.
+
+        Examples:
+          $ ernest env init
+          $ ernest env init --provider aws --name staging --project shop
+          $ ernest env init --provider azure --preset vpc-only --name network --project shop --dir infra
+    watch:
+      usage: "Dry runs a definition every time it changes."
+      args: "<file.yml>"
+      description: |
+        Watches an environment YAML description file, and every file it references
+        with @{...}, and every time they change validates the definition locally and
+        shows the changes applying it would perform, redrawn in place. Files are
+        checked every --interval, and a change is only dry run once files stay
+        unchanged for --debounce.
+
+        If the file is not provided, ernest.yml will be used by default.
+
+        With --apply the changes are also applied, once per change of the files. It is
+        only allowed on sandbox environments, the ones matching a project/env pattern
+        of the sandboxes list of your ~/.ernest config, like
+        "sandboxes": ["my_project/dev-*"]. Protected environments are never sandboxes.
+
+        Examples:
+          $ ernest env watch myenvironment.yml
+          $ ernest env watch --debounce 3s myenvironment.yml
+          $ ernest env watch --apply dev.yml
     destroy:
       usage: "Destroy an environment."
       args: "<project> <environment_name>"
       description: |
-        Destroys an environment by name.
+        Destroys an environment by name. The components of its latest build are listed
+        before asking to confirm it, which --yes skips.
+
+        Protected environments, see 'ernest env protect', can only be destroyed by
+        typing their full project/env name, or with --i-know. --yes doesn't skip it.
+
+        The pre-destroy, post-destroy and on-failure hooks of your ~/.ernest config
+        run around the destroy, a failing pre-destroy hook aborting it. --force
+        doesn't run hooks.
+
+        Use --report junit=<file> or --report tap=<file> to write the build outcome
+        as test results.
 
         Example:
           $ ernest env delete <my_project> <my_environment>
+          $ ernest env delete --report tap=ernest.tap <my_project> <my_environment>
     history:
       usage: "Shows the history of an environment, a list of builds"
       args: "ernest-cli env history <my_project> <my_env>"
       description: |
         Shows the history of an environment, a list of builds and its status and basic information.
+        The build currently deployed, the newest successful one, is marked with *.
+
+        Builds can be filtered by --status, --user and creation date with --since and
+        --until, and paginated with --limit and --page. Use --with-changes to show the
+        number of components every build added (+), changed (~) and removed (-) from
+        the previous build.
+
+        Builds applied with --git or --message show the commit they were applied from,
+        marked with + when there were uncommitted changes, and their message.
 
         Example:
           $ ernest env history <my_project> <my_env>
+          $ ernest env history --status errored --since 2017-09-01 <my_project> <my_env>
+          $ ernest env history --limit 10 --page 2 <my_project> <my_env>
+          $ ernest env history --user john --with-changes <my_project> <my_env>
+      export:
+        usage: "Exports the history of an environment to a git repository."
+        args: "<project_name> <env_name> <folder>"
+        description: |
+          Commits the definition of every finished build of an environment, from the
+          oldest to the newest, to a git repository on the given folder, so the evolution
+          of the environment can be followed with git tooling. The folder and the
+          repository are created if they don't exist.
+
+          Each commit is authored by the user that created the build on the date it was
+          created, and its message has the build status and ID. Running it again only
+          commits the builds created since the last export. The export stops at a build
+          still in progress, so the history stays in order, and resumes from it on the
+          next run.
+
+          Example:
+            $ ernest env history export <my_project> <my_env> ./my_env-history
+            $ git -C ./my_env-history log -p
     reset:
       usage: "Reset an in progress environment."
       args: "<env_name>"
@@ -233,21 +371,34 @@ var _langEnYml = []byte(`en:
           $ ernest env reset <my_env>
     revert:
       usage: "Reverts an environment to a previous state"
-      args: "<project> <env_name> <build_id>"
+      args: "<project> <env_name> <build>"
       description: |
-        Reverts an environment to a previous known state using a build ID from 'ernest env history'.
+        Reverts an environment to a previous known state using a build reference.
+        The definition of that build is applied as a new build, tagged as a revert
+        of it on 'ernest env history'. The changes the revert will perform are shown
+        and confirmed before applying them, unless --yes is given.
+
+        Builds can be referenced by their index on 'ernest env history', by their
+        full or short build ID, or with latest, previous, latest~N (the Nth build
+        before the latest one) and @<date> (the build current at that date).
 
         Example:
-          $ ernest env revert <project> <env_name> <build_id>
-          $ ernest env revert --dry <project> <env_name> <build_id>
+          $ ernest env revert <project> <env_name> <build>
+          $ ernest env revert --dry <project> <env_name> previous
     definition:
       usage: "Show the current definition of an environment by its name"
       args: "<project_name> <env_name>"
       description: | 
         Show the current definition of an environment by its name getting the definition about the build.
+        In case you specify --build option you will get the definition of a specific build.
+
+        Builds can be referenced by their index on 'ernest env history', by their
+        full or short build ID, or with latest, previous, latest~N (the Nth build
+        before the latest one) and @<date> (the build current at that date).
 
         Example:
           $ ernest env definition <my_project> <my_env>
+          $ ernest env definition <my_project> <my_env> --build latest~2
     info:
       usage: "$ ernest env info <my_env> --build <specific build>"
       args: "<project_name> <env_name>"
@@ -255,25 +406,207 @@ var _langEnYml = []byte(`en:
         Will show detailed information of the last build of a specified environment.
         In case you specify --build option you will be able to output the detailed information of specific build of an environment.
 
+        Builds can be referenced by their index on 'ernest env history', by their
+        full or short build ID, or with latest, previous, latest~N (the Nth build
+        before the latest one) and @<date> (the build current at that date).
+
+        The git commit, branch, remote and message the build was applied with are shown
+        when it was applied with --git or --message.
+
+        Every component type of the build is shown, with a table per type. Use --columns
+        to choose the columns shown for a type, as type=column,column with the field
+        names of the build payload. The option can be repeated for several types.
+
         Examples:
-          $ ernest env definition <my_project> <my_env>
-          $ ernest env definition <my_project> <my_env> --build build1
+          $ ernest env info <my_project> <my_env>
+          $ ernest env info --columns s3_buckets=name,bucket_location,acl <my_project> <my_env>
+          $ ernest env info --columns instances=name,instance_type,public_ip <my_project> <my_env>
+          $ ernest env info <my_project> <my_env> --build 3f2a9c1b
+          $ ernest env info <my_project> <my_env> --build @2017-09-21
     diff:
       usage: "$ ernest env diff <project_name> <env_name> <build_a> <build_b>"
       args: "<env_aname> <build_a> <build_b>"
       description: |
         Will display the diff between two different builds
 
+        Builds can be referenced by their index on 'ernest env history', by their
+        full or short build ID, or with latest, previous, latest~N (the Nth build
+        before the latest one) and @<date> (the build current at that date).
+
         Examples:
           $ ernest env diff <my_project> <my_env> 1 2
+          $ ernest env diff <my_project> <my_env> previous latest
     import:
       usage: "$ ernest env import <my_project> <my_env>"
       args: "<env_name>"
       description : |
         Will import the environment <my_env> from project <project_name>
 
+        Use --write <file> to write the definition of the imported environment to a
+        file, as 'ernest env export' does, to manage it declaratively from then on.
+
+        Use --report junit=<file> or --report tap=<file> to write the build outcome
+        as test results.
+
         Examples:
           $ ernest env import my_project my_env
+          $ ernest env import --report junit=import.xml my_project my_env
+          $ ernest env import --write ernest.yml my_project my_env
+    export:
+      usage: "Writes the definition of an environment from its latest build."
+      args: "<project_name> <env_name>"
+      description: |
+        Generates a clean definition from the latest build of an environment, like an
+        imported one, so it can be committed and managed declaratively. The ids and the
+        fields computed by ernest or the provider, like public ips or dns names, are
+        removed, as well as empty values and the client metadata.
+
+        The definition is printed, or written to a file with --write, which fails when
+        the file exists unless --overwrite is given. When written to a file, values
+        longer than --blob-size, like user data scripts, are written to files next to
+        it, named after their component and field, and referenced with @{file}.
+        References are relative to the current folder, as on 'ernest env apply'.
+
+        Examples:
+          $ ernest env export my_project my_env
+          $ ernest env export --write ernest.yml my_project my_env
+          $ ernest env export --write infra/ernest.yml --overwrite my_project my_env
+    drift:
+      usage: "Finds the changes made to an environment outside of Ernest."
+      args: "<project_name> <env_name>"
+      description: |
+        Discovers the live state of an environment, importing it into a temporary shadow
+        environment, and compares the discovered components with the ones of its latest
+        successful build, field by field. The discovery is limited to the resources of the
+        environment with the import filters given with --filters, as on 'ernest env
+        import', filtering by the environment name by default.
+
+        The report lists the drifted components with the fields that changed, the
+        missing components that were applied but not found, and the unmanaged
+        components that were found but never applied. Fields the provider doesn't
+        report, or not set on the build, are not compared.
+
+        The shadow environment is removed afterwards, without destroying the resources
+        it discovered. The command exits with 9 when there is drift.
+
+        Examples:
+          $ ernest env drift <my_project> <my_env>
+          $ ernest env drift --filters my_env <my_project> <my_env>
+    graph:
+      usage: "Exports the dependency graph of a definition as DOT or Mermaid."
+      args: "[file | <project_name> <env_name>]"
+      description: |
+        Builds a graph with a node per component and an edge per reference between
+        components, like an instance to its network or a network to its vpc or nat
+        gateway. With no arguments ernest.yml is read, with one argument the given
+        definition file, and with a project and environment the definition of its latest
+        build.
+
+        Use --format to print the graph for Graphviz (dot, the default) or Mermaid. With
+        --status the nodes are colored by the state of the components on the latest build
+        of the environment: done, errored, pending or not built.
+
+        Examples:
+          $ ernest env graph | dot -Tsvg > graph.svg
+          $ ernest env graph --format mermaid myapp.yml
+          $ ernest env graph --status <my_project> <my_env>
+    outputs:
+      usage: "Shows the values of the components of an environment for scripting."
+      args: "<project_name> <env_name> [output]"
+      description: |
+        Shows the values of the components of an environment, like instance IPs, ELB DNS
+        names or RDS endpoints, as a flat map addressed as <collection>.<name>.<field>,
+        for example instances.web-1.public_ip or elbs.api.dns_name.
+
+        Outputs are printed as json by default. Use --format dotenv to print them as
+        KEY="value" lines, or --format export to print them as shell export lines, with
+        keys converted to variable names like INSTANCES_WEB_1_PUBLIC_IP.
+
+        When an output is specified only its value is printed.
+
+        Examples:
+          $ ernest env outputs <my_project> <my_env>
+          $ ernest env outputs <my_project> <my_env> elbs.api.dns_name
+          $ ernest env outputs --format dotenv <my_project> <my_env> > .env
+          $ eval "$(ernest env outputs --format export <my_project> <my_env>)"
+    inventory:
+      usage: "Generates an Ansible inventory or SSH config of an environment."
+      args: "<project_name> <env_name>"
+      description: |
+        Generates an inventory of the instances and virtual machines of an environment,
+        grouped by the name of their instance group, so web-1 and web-2 are on the web
+        group.
+
+        Use --format to choose between an Ansible ini inventory (ansible-ini, the
+        default), an Ansible yaml inventory (ansible-yaml) or an SSH config file
+        (ssh-config). Hosts are reached on their public ip, or on their private ip with
+        --private. Hosts without such an ip are skipped.
+
+        Examples:
+          $ ernest env inventory <my_project> <my_env> > hosts
+          $ ernest env inventory --format ansible-yaml --user ubuntu <my_project> <my_env>
+          $ ernest env inventory --format ssh-config --private <my_project> <my_env>
+    protect:
+      usage: "Protects environments from being destroyed by accident."
+      args: "[project/env pattern]"
+      description: |
+        Protects the environments matching a project/env glob pattern, saving it on
+        your local config. Destroying a protected environment requires typing its full
+        name, and 'ernest env gc' skips them. With no pattern, lists the protection
+        patterns.
+
+        Examples:
+          $ ernest env protect 'my_project/prod*'
+          $ ernest env protect '*/production'
+          $ ernest env protect
+    unprotect:
+      usage: "Removes an environment protection pattern."
+      args: "<project/env pattern>"
+      description: |
+        Removes a protection pattern added with 'ernest env protect'.
+
+        Example:
+          $ ernest env unprotect 'my_project/prod*'
+    gc:
+      usage: "Destroys the environments not built for a while."
+      args: "[project/env pattern]"
+      description: |
+        Finds the environments matching a project/env glob pattern, all of them by
+        default, whose latest build was created longer than --older-than ago and, when
+        --idle is given, finished longer than --idle ago. Environments being built or
+        without builds are never destroyed. Durations accept days, like 7d or 1d12h.
+
+        The environments found are listed with their age and the user that created
+        them, and destroyed after confirming it, or directly with --yes. At most
+        --concurrency environments are destroyed at the same time, and a report of
+        the outcome of every environment is shown at the end.
+
+        With --dry the environments are only listed, and the command exits with 9 when
+        there are environments to destroy.
+
+        Protected environments are skipped, unless --i-know is given.
+
+        Examples:
+          $ ernest env gc --older-than 7d --dry
+          $ ernest env gc 'my_project/feature-*' --older-than 3d --idle 1d
+          $ ernest env gc 'my_project/*' --older-than 14d --yes --concurrency 4
+    timings:
+      usage: "Shows how long each component of a build took."
+      args: "<project_name> <env_name>"
+      description: |
+        Computes how long each component of a build spent running, the critical path
+        of the build, the slowest component types and the build wall time, and draws
+        them as a timeline.
+
+        Timings are computed following an in progress build until it finishes, with its
+        progress shown on stderr, or from a build recorded with the --record option of
+        'ernest env apply', using --recording. Builds that have already finished can only
+        be timed from a recording.
+
+        Examples:
+          $ ernest env timings <my_project> <my_env>
+          $ ernest env timings <my_project> <my_env> --build latest
+          $ ernest env timings --recording build.events
   log:
     usage: "Inline display of ernest logs."
     args: " "
@@ -310,8 +643,29 @@ var _langEnYml = []byte(`en:
     description: |
       Monitors an environment while it is being built by its name.
 
+      Lost connections are automatically resumed from the last received event.
+      When no events are received for --idle-timeout, the build status is polled instead.
+
       Example:
         $ ernest monitor <my_project> <my_env>
+        $ ernest monitor --idle-timeout 5m <my_project> <my_env>
+        $ ernest monitor --verbose <my_project> <my_env>
+        $ ernest monitor --progress plain <my_project> <my_env>
+        $ ernest monitor --progress json <my_project> <my_env>
+        $ ernest monitor --record build.events <my_project> <my_env>
+  replay:
+    usage: "Replays a recorded build."
+    args: "<recording_file>"
+    description: |
+      Renders the events of a build recorded with the --record option of 'ernest env apply',
+      'ernest env import' or 'ernest monitor' as they were received.
+
+      Example:
+        $ ernest env apply --record build.events ernest.yml
+        $ ernest replay build.events
+        $ ernest replay --speed 10 --verbose build.events
+        $ ernest replay --speed 0 --progress json build.events
+        $ ernest replay --speed 0 --report junit=ernest.xml build.events
   notification:
     list:
       usage: "List available notifications."
@@ -526,7 +880,7 @@ func langEnYml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "lang/en.yml", size: 15122, mode: os.FileMode(420), modTime: time.Unix(1504859240, 0)}
+	info := bindataFileInfo{name: "lang/en.yml", size: 34374, mode: os.FileMode(420), modTime: time.Unix(1504859240, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
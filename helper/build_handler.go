@@ -7,26 +7,33 @@ package helper
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"os"
+	"time"
 
-	"github.com/ernestio/ernest-cli/model"
-	"github.com/gosuri/uilive"
 	"github.com/r3labs/sse"
 )
 
+// errBuildFailed is returned when a followed build finishes with errors
+var errBuildFailed = NewError(ExitBuildFailed, "service task failed with errors")
+
 type buildhandler struct {
-	stream    chan *sse.Event
-	writer    *uilive.Writer
-	format    string
-	failures  []error
-	args      []interface{}
-	service   model.BuildEvent
-	component model.ComponentEvent
+	stream   chan *sse.Event
+	errors   chan error
+	output   progress
+	recorder *recorder
+	idle     time.Duration
+	poll     func() (string, error)
+	polling  bool
 }
 
 func (h *buildhandler) subscribe() error {
 	for {
+		var idle <-chan time.Time
+		if h.idle > 0 && h.poll != nil {
+			idle = time.After(h.idle)
+		}
+
 		select {
 		case msg, ok := <-h.stream:
 			if !ok {
@@ -37,53 +44,93 @@ func (h *buildhandler) subscribe() error {
 				continue
 			}
 
-			// clean msg body of any null characters
-			cleanedInput := bytes.Trim(msg.Data, "\x00")
-
-			m := make(map[string]interface{})
-
-			err := json.Unmarshal(cleanedInput, &m)
-			if err != nil {
+			done, err := h.handle(msg, time.Now())
+			if done || err != nil {
 				return err
 			}
-
-			subject := m["_subject"].(string)
-
-			switch subject {
-			case BUILDCREATE, BUILDDELETE, BUILDIMPORT:
-				h.service = processBuildEvent(m)
-				h.format, h.args = renderOutput(h.service)
-			case BUILDCREATEDONE, BUILDCREATEERROR, BUILDDELETEDONE, BUILDDELETEERROR, BUILDIMPORTDONE, BUILDIMPORTERROR:
-				h.service = processBuildEvent(m)
-				err = renderUpdate(h.service, model.ComponentEvent{}, h.args)
-				if err != nil {
-					return err
-				}
-			default:
-				h.component = processComponentEvent(m)
-				cerr := renderUpdate(model.BuildEvent{}, h.component, h.args)
-				if cerr != nil {
-					h.failures = append(h.failures, cerr)
-				}
+		case err := <-h.errors:
+			if h.poll == nil || h.idle <= 0 {
+				return err
 			}
-
-			fmt.Fprintf(h.writer, h.format, h.args...)
-
-			err = h.writer.Flush()
-			if err != nil {
+			// keep following the build by polling its status
+			h.errors = nil
+		case <-idle:
+			done, err := h.check()
+			if done || err != nil {
 				return err
 			}
+		}
+	}
+}
 
-			switch subject {
-			case BUILDCREATEDONE, BUILDDELETEDONE, BUILDIMPORTDONE:
-				return nil
-			case BUILDCREATEERROR, BUILDDELETEERROR, BUILDIMPORTERROR:
-				for _, resourceErr := range h.failures {
-					fmt.Printf("Message: %s\n\n", red(resourceErr))
-				}
-				return errors.New("service task failed with errors")
-			}
+// handle : renders a stream event, returning true once the build has finished
+func (h *buildhandler) handle(msg *sse.Event, at time.Time) (bool, error) {
+	// clean msg body of any null characters
+	cleanedInput := bytes.Trim(msg.Data, "\x00")
 
+	m := make(map[string]interface{})
+
+	err := json.Unmarshal(cleanedInput, &m)
+	if err != nil {
+		return false, err
+	}
+
+	if h.recorder != nil {
+		if err := h.recorder.record(msg, cleanedInput, at); err != nil {
+			return false, err
 		}
 	}
+
+	subject := m["_subject"].(string)
+
+	switch subject {
+	case BUILDCREATE, BUILDDELETE, BUILDIMPORT, BUILDCREATEDONE, BUILDCREATEERROR, BUILDDELETEDONE, BUILDDELETEERROR, BUILDIMPORTDONE, BUILDIMPORTERROR:
+		err = h.output.build(processBuildEvent(m), at)
+	default:
+		err = h.output.component(processComponentEvent(m), at)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	switch subject {
+	case BUILDCREATEDONE, BUILDDELETEDONE, BUILDIMPORTDONE:
+		return true, nil
+	case BUILDCREATEERROR, BUILDDELETEERROR, BUILDIMPORTERROR:
+		return true, errBuildFailed
+	}
+
+	return false, nil
+}
+
+// check : polls the build status when no events have been received for a
+// while, returning true once the build has finished. Errors that polling
+// again won't fix, like an expired session or a removed build, stop it
+func (h *buildhandler) check() (bool, error) {
+	status, err := h.poll()
+	if err != nil {
+		switch ExitCode(err) {
+		case ExitAuth, ExitPermission, ExitNotFound:
+			return true, err
+		}
+		return false, nil
+	}
+
+	if !h.polling {
+		h.polling = true
+		fmt.Fprintf(os.Stderr, "No build events received in %s, checking the build status every %s\n", h.idle, h.idle)
+	}
+
+	switch status {
+	case "done":
+		return true, h.output.polled(status)
+	case "errored":
+		if err := h.output.polled(status); err != nil {
+			return true, err
+		}
+		return true, errBuildFailed
+	}
+
+	return false, nil
 }
@@ -0,0 +1,59 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/r3labs/sse"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildHandler(t *testing.T) {
+	Convey("Given a build handler falling back to polling", t, func() {
+		var pollErr error
+		h := buildhandler{
+			stream: make(chan *sse.Event),
+			errors: make(chan error, 1),
+			output: newPlainProgress(ioutil.Discard, "", false),
+			idle:   time.Millisecond,
+			poll: func() (string, error) {
+				return "in_progress", pollErr
+			},
+		}
+
+		Convey("When polling fails with an error polling again won't fix", func() {
+			pollErr = NewStatusError(404, "Specified build not found")
+			done, err := h.check()
+
+			Convey("It should stop following the build", func() {
+				So(done, ShouldBeTrue)
+				So(ExitCode(err), ShouldEqual, ExitNotFound)
+			})
+		})
+
+		Convey("When polling fails with a transient error", func() {
+			pollErr = errors.New("connection reset")
+			done, err := h.check()
+
+			Convey("It should keep following the build", func() {
+				So(done, ShouldBeFalse)
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When the stream fails and the idle timeout is disabled", func() {
+			h.idle = 0
+			h.errors <- errors.New("stream closed")
+
+			Convey("It should return the stream error", func() {
+				So(h.subscribe(), ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -10,8 +10,100 @@ import (
 	"github.com/fatih/color"
 )
 
-// PrintError : prints an error and returns
-func PrintError(msg string) {
-	color.Red(msg)
-	os.Exit(1)
+// Exit codes, documented on the README
+const (
+	ExitOK             = 0
+	ExitError          = 1
+	ExitUsage          = 2
+	ExitAuth           = 3
+	ExitPermission     = 4
+	ExitNotFound       = 5
+	ExitConflict       = 6
+	ExitBuildFailed    = 7
+	ExitTimeout        = 8
+	ExitChangesPending = 9
+)
+
+// ErrNotLoggedIn is returned when an action needs a logged in user
+var ErrNotLoggedIn = NewError(ExitAuth, "You're not allowed to perform this action, please log in")
+
+// ErrNoPermission is returned when the user is not allowed to perform an action
+var ErrNoPermission = NewError(ExitPermission, "You don’t have permissions to perform this action")
+
+// ErrChangesPending is returned by dry runs when applying would change the environment
+var ErrChangesPending = NewError(ExitChangesPending, "The environment has pending changes")
+
+// Error : an error classified by the exit code it causes
+type Error struct {
+	Code    int
+	Message string
+}
+
+// Error : returns the error message
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// ExitCode : returns the process exit code of the error
+func (e *Error) ExitCode() int {
+	return e.Code
+}
+
+// NewError : creates an error exiting with the given code
+func NewError(code int, msg string) error {
+	return &Error{Code: code, Message: msg}
+}
+
+// NewStatusError : creates an error for an api response status
+func NewStatusError(status int, msg string) error {
+	switch status {
+	case 401:
+		return NewError(ExitAuth, msg)
+	case 403:
+		return NewError(ExitPermission, msg)
+	case 404:
+		return NewError(ExitNotFound, msg)
+	case 409:
+		return NewError(ExitConflict, msg)
+	case 408, 504:
+		return NewError(ExitTimeout, msg)
+	}
+
+	return NewError(ExitError, msg)
+}
+
+// WithMessage : replaces the message of an error keeping its exit code
+func WithMessage(err error, msg string) error {
+	return NewError(ExitCode(err), msg)
+}
+
+// ExitCode : returns the process exit code of an error
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	switch e := err.(type) {
+	case *Error:
+		return e.Code
+	case interface {
+		Timeout() bool
+	}:
+		if e.Timeout() {
+			return ExitTimeout
+		}
+	}
+
+	return ExitError
+}
+
+// PrintUsageError : prints an error on the command usage and exits
+func PrintUsageError(msg string) {
+	Fail(NewError(ExitUsage, msg))
+}
+
+// Fail : prints an error and exits with its exit code
+func Fail(err error) {
+	color.Red(err.Error())
+	os.Exit(ExitCode(err))
 }
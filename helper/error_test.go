@@ -0,0 +1,43 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string { return "i/o timeout" }
+func (timeoutError) Timeout() bool { return true }
+
+func TestExitCode(t *testing.T) {
+	Convey("Given errors of different classes", t, func() {
+		Convey("It should exit with the code of the error class", func() {
+			So(ExitCode(nil), ShouldEqual, ExitOK)
+			So(ExitCode(errors.New("unexpected")), ShouldEqual, ExitError)
+			So(ExitCode(ErrNotLoggedIn), ShouldEqual, ExitAuth)
+			So(ExitCode(errBuildFailed), ShouldEqual, ExitBuildFailed)
+			So(ExitCode(timeoutError{}), ShouldEqual, ExitTimeout)
+		})
+
+		Convey("It should classify api response statuses", func() {
+			So(ExitCode(NewStatusError(401, "")), ShouldEqual, ExitAuth)
+			So(ExitCode(NewStatusError(403, "")), ShouldEqual, ExitPermission)
+			So(ExitCode(NewStatusError(404, "")), ShouldEqual, ExitNotFound)
+			So(ExitCode(NewStatusError(409, "")), ShouldEqual, ExitConflict)
+			So(ExitCode(NewStatusError(500, "")), ShouldEqual, ExitError)
+		})
+
+		Convey("It should keep the exit code when replacing the message", func() {
+			err := WithMessage(NewStatusError(409, "409 Conflict"), "Project already exists")
+			So(err.Error(), ShouldEqual, "Project already exists")
+			So(ExitCode(err), ShouldEqual, ExitConflict)
+		})
+	})
+}
@@ -0,0 +1,158 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+var errGitNotFound = errors.New("git is required, please install it")
+
+// GitCommit : the details of a commit
+type GitCommit struct {
+	Author  string
+	Email   string
+	Date    string
+	Message string
+}
+
+// GitInit : creates a git repository on a folder, if it isn't one already
+func GitInit(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.New("Can't create folder " + dir)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		return nil
+	}
+
+	_, err := git(dir, nil, "init", "-q")
+	return err
+}
+
+// GitTrailers : returns the values of a trailer on the commits of a repository
+func GitTrailers(dir, key string) ([]string, error) {
+	if _, err := git(dir, nil, "rev-parse", "--verify", "-q", "HEAD"); err != nil {
+		// the repository has no commits yet
+		return nil, nil
+	}
+
+	out, err := git(dir, nil, "log", "--format=%B")
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, key+": ") {
+			values = append(values, strings.TrimSpace(strings.TrimPrefix(line, key+": ")))
+		}
+	}
+
+	return values, nil
+}
+
+// GitCommitFiles : commits the given files of a repository, the commit is
+// created even if the files didn't change
+func GitCommitFiles(dir string, files []string, c GitCommit) error {
+	if _, err := git(dir, nil, append([]string{"add", "--"}, files...)...); err != nil {
+		return err
+	}
+
+	// the author is also the committer, so exports are reproducible
+	env := []string{
+		"GIT_AUTHOR_NAME=" + c.Author,
+		"GIT_AUTHOR_EMAIL=" + c.Email,
+		"GIT_AUTHOR_DATE=" + c.Date,
+		"GIT_COMMITTER_NAME=" + c.Author,
+		"GIT_COMMITTER_EMAIL=" + c.Email,
+		"GIT_COMMITTER_DATE=" + c.Date,
+	}
+
+	_, err := git(dir, env, "commit", "-q", "--allow-empty", "-m", c.Message)
+	return err
+}
+
+// GitInfo : the state of the working tree of a git repository
+type GitInfo struct {
+	Commit string
+	Branch string
+	Dirty  bool
+	Remote string
+}
+
+// GitWorkTree : returns the state of the git working tree a folder belongs
+// to, or nil when it isn't on one
+func GitWorkTree(dir string) (*GitInfo, error) {
+	if _, err := git(dir, nil, "rev-parse", "--is-inside-work-tree"); err != nil {
+		if err == errGitNotFound {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	var info GitInfo
+
+	// repositories without commits have no sha nor branch yet
+	if out, err := git(dir, nil, "rev-parse", "--verify", "-q", "HEAD"); err == nil {
+		info.Commit = strings.TrimSpace(out)
+	}
+	if out, err := git(dir, nil, "symbolic-ref", "-q", "--short", "HEAD"); err == nil {
+		info.Branch = strings.TrimSpace(out)
+	}
+
+	out, err := git(dir, nil, "status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	info.Dirty = strings.TrimSpace(out) != ""
+
+	if out, err := git(dir, nil, "config", "--get", "remote.origin.url"); err == nil {
+		info.Remote = stripCredentials(strings.TrimSpace(out))
+	}
+
+	return &info, nil
+}
+
+// stripCredentials : removes the user and password of a remote url, so
+// tokens used to clone never leave the machine
+func stripCredentials(remote string) string {
+	u, err := url.Parse(remote)
+	if err != nil || u.User == nil || u.Scheme == "" {
+		return remote
+	}
+	u.User = nil
+	return u.String()
+}
+
+// git : runs a git command on a repository, returning its output
+func git(dir string, env []string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.Error); ok {
+			return "", errGitNotFound
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", errors.New("git " + args[0] + " failed: " + msg)
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,37 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// RunHook : runs the commands of a hook on the local shell, stopping on the
+// first one failing. Their output goes to stderr, so it never mixes with
+// machine readable output
+func RunHook(name string, commands []string, env []string) error {
+	for _, command := range commands {
+		cmd := shell(command)
+		cmd.Env = append(os.Environ(), env...)
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+
+		if err := cmd.Run(); err != nil {
+			return errors.New("The " + name + " hook '" + command + "' failed: " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+func shell(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
@@ -5,14 +5,13 @@
 package helper
 
 import (
-	"crypto/tls"
 	"fmt"
-	"net/http"
+	"io"
 	"os"
+	"time"
 
 	"github.com/fatih/color"
-	"github.com/gosuri/uilive"
-	"github.com/r3labs/sse"
+	isatty "github.com/mattn/go-isatty"
 )
 
 const (
@@ -33,17 +32,104 @@ var (
 	red    = color.New(color.FgRed).SprintFunc()
 )
 
+// colors are disabled when the output is not a terminal or NO_COLOR is set
+func init() {
+	if os.Getenv("NO_COLOR") != "" || !isTerminal() {
+		color.NoColor = true
+	}
+}
+
+// MonitorOptions : settings used to follow a build stream
+type MonitorOptions struct {
+	// IdleTimeout is the time without events after which the build
+	// status is polled, zero disables polling
+	IdleTimeout time.Duration
+	// Verbose lists every component of the build instead of a count per type
+	Verbose bool
+	// Progress is the progress output format, defaults to live on
+	// terminals and plain elsewhere
+	Progress string
+	// Record is the path of a file where the raw build events are recorded
+	Record string
+	// Report collects the build outcome as test results when set
+	Report *Report
+	// Name identifies the environment being built on reports
+	Name string
+	// Prefix starts every plain progress line with the environment name, so
+	// the progress of builds followed at the same time can be told apart
+	Prefix bool
+	// Poll returns the current status of the build being followed
+	Poll func() (string, error)
+	// Output is where the progress is written, defaults to the standard output
+	Output io.Writer
+	// live is set when following a build as it runs, rather than replaying it
+	live bool
+}
+
 // Monitorize opens a websocket connection to get input messages
-func Monitorize(host, endpoint, token, stream string) error {
+func Monitorize(host, endpoint, token, stream string, opts MonitorOptions) error {
+	s := subscribe(host, endpoint, token, stream)
+	defer s.close()
+
+	opts.live = true
+
 	h := buildhandler{
-		writer: uilive.New(),
-		stream: OpenStream(host, endpoint, token, stream),
+		output: newBuildOutput(opts),
+		stream: s.events,
+		errors: s.errors,
+		idle:   opts.IdleTimeout,
+		poll:   opts.Poll,
 	}
+	defer h.output.stop()
 
-	h.writer.Start()
-	defer h.writer.Stop()
+	if opts.Record != "" {
+		r, err := newRecorder(opts.Record)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = r.close()
+		}()
+		h.recorder = r
+	}
 
-	return h.subscribe()
+	return writeReport(opts.Report, h.subscribe())
+}
+
+// output : returns the writer the progress is written to
+func (o MonitorOptions) output() io.Writer {
+	if o.Output == nil {
+		return os.Stdout
+	}
+	return o.Output
+}
+
+// newBuildOutput : returns the progress output of a build, also collecting
+// its outcome on the report when one was requested
+func newBuildOutput(opts MonitorOptions) progress {
+	if opts.Report == nil {
+		return newProgress(opts)
+	}
+
+	return teeprogress{newProgress(opts), opts.Report.suite(opts.Name)}
+}
+
+// writeReport : writes the report once a build has finished, returning the
+// build error if any
+func writeReport(r *Report, err error) error {
+	if r == nil {
+		return err
+	}
+
+	if werr := r.write(); werr != nil {
+		if err != nil {
+			fmt.Fprintln(os.Stderr, werr.Error())
+			return err
+		}
+		return werr
+	}
+
+	return err
 }
 
 // PrintLogs : prints logs inline
@@ -64,27 +150,16 @@ func PrintRawLogs(host, endpoint, token, stream string) error {
 	return h.subscribe()
 }
 
-// OpenStream : opens an sse stream
-func OpenStream(host, endpoint, token, stream string) chan *sse.Event {
-	ec := make(chan *sse.Event, 1024)
-
-	client := sse.NewClient(host + endpoint)
+// isTerminal : returns true when the standard output is a terminal
+func isTerminal() bool {
+	return isTerminalWriter(os.Stdout)
+}
 
-	//client.EventID = "0"
-	client.EncodingBase64 = true
-	client.Connection.Transport = &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+// isTerminalWriter : returns true when the given writer is a terminal
+func isTerminalWriter(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
 	}
-
-	client.Headers["Authorization"] = fmt.Sprintf("Bearer %s", token)
-
-	go func() {
-		err := client.SubscribeChan(stream, ec)
-		if err != nil {
-			fmt.Println("error connecting to stream: " + err.Error())
-			os.Exit(1)
-		}
-	}()
-
-	return ec
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
 }
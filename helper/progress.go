@@ -0,0 +1,150 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/gosuri/uilive"
+)
+
+// progress renders the events of a build stream
+type progress interface {
+	// build renders a build event, including the final done and error ones
+	build(s model.BuildEvent, at time.Time) error
+	// component renders a component event
+	component(c model.ComponentEvent, at time.Time) error
+	// polled renders a build status obtained by polling the api
+	polled(status string) error
+	// stop releases the output once the build has finished
+	stop()
+}
+
+// Progress output formats
+const (
+	PROGRESSLIVE  = "live"
+	PROGRESSPLAIN = "plain"
+	PROGRESSJSON  = "json"
+)
+
+// ProgressFormats lists the accepted progress output formats
+var ProgressFormats = []string{PROGRESSLIVE, PROGRESSPLAIN, PROGRESSJSON}
+
+// newProgress : returns the build progress renderer for the given options,
+// rendering in place on terminals and line by line elsewhere by default
+func newProgress(opts MonitorOptions) progress {
+	out := opts.output()
+	format := opts.Progress
+	if format == "" {
+		format = PROGRESSPLAIN
+		if isTerminalWriter(out) {
+			format = PROGRESSLIVE
+		}
+	}
+
+	switch {
+	case format == PROGRESSJSON:
+		return newJSONProgress(out)
+	case format == PROGRESSPLAIN:
+		prefix := ""
+		if opts.Prefix {
+			prefix = "[" + opts.Name + "] "
+		}
+		return newPlainProgress(out, prefix, opts.Verbose)
+	case opts.Verbose:
+		return newVerboseProgress(out, opts.live)
+	}
+
+	return newLiveProgress(out)
+}
+
+// liveprogress renders a count of components per type, rewriting it in place
+type liveprogress struct {
+	writer   *uilive.Writer
+	format   string
+	args     []interface{}
+	failures []error
+}
+
+func newLiveProgress(out io.Writer) *liveprogress {
+	p := liveprogress{writer: uilive.New()}
+	p.writer.Out = out
+	p.writer.Start()
+	return &p
+}
+
+func (p *liveprogress) build(s model.BuildEvent, at time.Time) error {
+	switch s.Subject {
+	case BUILDCREATE, BUILDDELETE, BUILDIMPORT:
+		p.format, p.args = renderOutput(s)
+	default:
+		if err := renderUpdate(s, model.ComponentEvent{}, p.args); err != nil {
+			return err
+		}
+	}
+
+	if err := p.flush(); err != nil {
+		return err
+	}
+
+	switch s.Subject {
+	case BUILDCREATEERROR, BUILDDELETEERROR, BUILDIMPORTERROR:
+		p.printFailures()
+	}
+
+	return nil
+}
+
+func (p *liveprogress) component(c model.ComponentEvent, at time.Time) error {
+	if err := renderUpdate(model.BuildEvent{}, c, p.args); err != nil {
+		p.failures = append(p.failures, err)
+	}
+
+	return p.flush()
+}
+
+func (p *liveprogress) polled(status string) error {
+	if len(p.args) > 0 {
+		switch status {
+		case "done":
+			p.args[len(p.args)-1] = green("Done")
+		case "errored":
+			p.args[len(p.args)-1] = red("Error")
+		}
+	}
+
+	if err := p.flush(); err != nil {
+		return err
+	}
+
+	if status == "errored" {
+		p.printFailures()
+	}
+
+	return nil
+}
+
+func (p *liveprogress) stop() {
+	p.writer.Stop()
+}
+
+func (p *liveprogress) flush() error {
+	if p.format == "" {
+		return nil
+	}
+
+	fmt.Fprintf(p.writer, p.format, p.args...)
+
+	return p.writer.Flush()
+}
+
+func (p *liveprogress) printFailures() {
+	for _, resourceErr := range p.failures {
+		fmt.Fprintf(p.writer.Out, "Message: %s\n\n", red(resourceErr))
+	}
+}
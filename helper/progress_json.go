@@ -0,0 +1,146 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/ernestio/ernest-cli/model"
+)
+
+// progressrecord is the normalized form of a build stream event
+type progressrecord struct {
+	Type          string `json:"type"`
+	Timestamp     string `json:"timestamp"`
+	BuildID       string `json:"build_id"`
+	Subject       string `json:"subject,omitempty"`
+	ComponentType string `json:"component_type,omitempty"`
+	ComponentName string `json:"component_name,omitempty"`
+	Action        string `json:"action,omitempty"`
+	State         string `json:"state,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// summaryrecord is written once the build has finished
+type summaryrecord struct {
+	Type       string   `json:"type"`
+	Timestamp  string   `json:"timestamp"`
+	BuildID    string   `json:"build_id"`
+	Name       string   `json:"name"`
+	Status     string   `json:"status"`
+	Duration   float64  `json:"duration_seconds"`
+	Components int      `json:"components"`
+	Completed  int      `json:"completed"`
+	Errored    int      `json:"errored"`
+	Errors     []string `json:"errors"`
+}
+
+// jsonprogress writes every build event as a json line
+type jsonprogress struct {
+	encoder *json.Encoder
+	service model.BuildEvent
+	started time.Time
+	states  map[string]string
+	errors  []string
+	written bool
+}
+
+func newJSONProgress(w io.Writer) *jsonprogress {
+	return &jsonprogress{
+		encoder: json.NewEncoder(w),
+		states:  make(map[string]string),
+		errors:  []string{},
+	}
+}
+
+func (p *jsonprogress) build(s model.BuildEvent, at time.Time) error {
+	switch s.Subject {
+	case BUILDCREATE, BUILDDELETE, BUILDIMPORT:
+		p.service = s
+		p.started = at
+		for _, c := range s.Changes {
+			p.states[c.Type+"::"+c.Name] = "pending"
+		}
+	}
+
+	err := p.encoder.Encode(progressrecord{
+		Type:      "build",
+		Timestamp: at.Format(time.RFC3339Nano),
+		BuildID:   s.ID,
+		Subject:   s.Subject,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch s.Subject {
+	case BUILDCREATEDONE, BUILDDELETEDONE, BUILDIMPORTDONE:
+		return p.summary("done", at)
+	case BUILDCREATEERROR, BUILDDELETEERROR, BUILDIMPORTERROR:
+		return p.summary("errored", at)
+	}
+
+	return nil
+}
+
+func (p *jsonprogress) component(c model.ComponentEvent, at time.Time) error {
+	p.states[c.Type+"::"+c.Name] = c.State
+	if c.Error != "" {
+		p.errors = append(p.errors, c.Error)
+	}
+
+	return p.encoder.Encode(progressrecord{
+		Type:          "component",
+		Timestamp:     at.Format(time.RFC3339Nano),
+		BuildID:       p.service.ID,
+		Subject:       c.Subject,
+		ComponentType: c.Type,
+		ComponentName: c.Name,
+		Action:        c.Action,
+		State:         c.State,
+		Error:         c.Error,
+	})
+}
+
+func (p *jsonprogress) polled(status string) error {
+	return p.summary(status, time.Now())
+}
+
+func (p *jsonprogress) stop() {}
+
+// summary : writes the final record of the build
+func (p *jsonprogress) summary(status string, at time.Time) error {
+	if p.written {
+		return nil
+	}
+	p.written = true
+
+	s := summaryrecord{
+		Type:       "summary",
+		Timestamp:  at.Format(time.RFC3339Nano),
+		BuildID:    p.service.ID,
+		Name:       p.service.Name,
+		Status:     status,
+		Components: len(p.states),
+		Errors:     p.errors,
+	}
+
+	if !p.started.IsZero() {
+		s.Duration = at.Sub(p.started).Seconds()
+	}
+
+	for _, state := range p.states {
+		switch state {
+		case "completed":
+			s.Completed++
+		case "errored":
+			s.Errored++
+		}
+	}
+
+	return p.encoder.Encode(s)
+}
@@ -0,0 +1,128 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ernestio/ernest-cli/model"
+)
+
+// plainprogress prints a timestamped line per build and component state
+// transition, so it can be followed on logs that are not terminals. When
+// verbose, finished components show the time they spent running
+type plainprogress struct {
+	out      io.Writer
+	prefix   string
+	verbose  bool
+	started  map[string]time.Time
+	failures []string
+}
+
+func newPlainProgress(out io.Writer, prefix string, verbose bool) *plainprogress {
+	return &plainprogress{out: out, prefix: prefix, verbose: verbose, started: make(map[string]time.Time)}
+}
+
+func (p *plainprogress) build(s model.BuildEvent, at time.Time) error {
+	switch s.Subject {
+	case BUILDCREATE, BUILDDELETE, BUILDIMPORT:
+		p.println(at, "Environment Name: "+s.Name)
+		p.println(at, "Build ID: "+s.ID)
+		p.println(at, "Changes: "+summarizeChanges(s.Changes))
+		p.println(at, "Status: "+yellow(buildStatus(s.Subject)))
+	case BUILDCREATEDONE, BUILDDELETEDONE, BUILDIMPORTDONE:
+		p.println(at, "Status: "+green(buildStatus(s.Subject)))
+	case BUILDCREATEERROR, BUILDDELETEERROR, BUILDIMPORTERROR:
+		p.println(at, "Status: "+red("Error"))
+		p.printFailures(at)
+	}
+
+	return nil
+}
+
+func (p *plainprogress) component(c model.ComponentEvent, at time.Time) error {
+	line := formatType(c.Type) + " " + c.Name + ": " + c.Action + " " + c.State
+
+	key := c.Type + "/" + c.Name
+	switch c.State {
+	case "running":
+		p.started[key] = at
+	case "completed", "errored":
+		if started, ok := p.started[key]; ok && p.verbose && at.After(started) {
+			line = line + " (" + formatElapsed(at.Sub(started)) + ")"
+		}
+		delete(p.started, key)
+	}
+
+	switch c.State {
+	case "errored":
+		p.println(at, red(line))
+		if c.Error != "" {
+			p.println(at, red("  Error: "+c.Error))
+			p.failures = append(p.failures, c.Error)
+		}
+	case "completed":
+		p.println(at, green(line))
+	default:
+		p.println(at, line)
+	}
+
+	for _, found := range c.Components {
+		p.println(at, green(formatType(found.Type)+" "+found.Name+": found"))
+	}
+
+	return nil
+}
+
+func (p *plainprogress) polled(status string) error {
+	switch status {
+	case "done":
+		p.println(time.Now(), "Status: "+green("Done"))
+	case "errored":
+		p.println(time.Now(), "Status: "+red("Error"))
+		p.printFailures(time.Now())
+	}
+
+	return nil
+}
+
+func (p *plainprogress) stop() {}
+
+func (p *plainprogress) println(at time.Time, line string) {
+	fmt.Fprintln(p.out, at.Format(time.RFC3339)+" "+p.prefix+line)
+}
+
+func (p *plainprogress) printFailures(at time.Time) {
+	for _, failure := range p.failures {
+		p.println(at, "Message: "+red(failure))
+	}
+}
+
+// summarizeChanges : describes the number of changes of a build per type
+func summarizeChanges(changes []model.ComponentEvent) string {
+	if len(changes) == 0 {
+		return "none"
+	}
+
+	counts := ParseChanges(changes)
+
+	var keys []string
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, formatType(k)+"s "+strconv.Itoa(counts[k]))
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,31 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ernestio/ernest-cli/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProgressOutput(t *testing.T) {
+	Convey("Given monitor options writing the progress to a buffer", t, func() {
+		var out bytes.Buffer
+
+		for _, format := range ProgressFormats {
+			Convey("It should write the "+format+" progress to it", func() {
+				p := newProgress(MonitorOptions{Progress: format, Output: &out})
+				_ = p.build(model.BuildEvent{ID: "b1", Name: "env", Subject: BUILDCREATE}, time.Now())
+				_ = p.build(model.BuildEvent{ID: "b1", Name: "env", Subject: BUILDCREATEDONE}, time.Now())
+				p.stop()
+
+				So(out.String(), ShouldContainSubstring, "b1")
+			})
+		}
+	})
+}
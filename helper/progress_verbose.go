@@ -0,0 +1,322 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/fatih/color"
+	"github.com/gosuri/uilive"
+)
+
+// componentprogress is the last known state of a build component
+type componentprogress struct {
+	kind     string
+	name     string
+	action   string
+	state    string
+	err      string
+	started  time.Time
+	finished time.Time
+}
+
+// elapsed : time the component has spent running by the given time
+func (c *componentprogress) elapsed(now time.Time) time.Duration {
+	switch {
+	case c.started.IsZero():
+		return 0
+	case c.finished.IsZero():
+		return now.Sub(c.started)
+	}
+	return c.finished.Sub(c.started)
+}
+
+// verboseprogress renders every component of a build as a tree grouped by
+// component type, redrawn in place and collapsing the groups that have
+// finished. Running times are measured against the latest event seen, so
+// replayed builds show the times they were recorded with
+type verboseprogress struct {
+	mu         sync.Mutex
+	writer     *uilive.Writer
+	done       chan bool
+	live       bool
+	now        time.Time
+	service    model.BuildEvent
+	status     string
+	types      []string
+	components map[string][]*componentprogress
+}
+
+func newVerboseProgress(out io.Writer, live bool) *verboseprogress {
+	p := verboseprogress{
+		writer:     uilive.New(),
+		done:       make(chan bool),
+		live:       live,
+		components: make(map[string][]*componentprogress),
+	}
+
+	p.writer.Out = out
+	p.writer.Start()
+	if live {
+		go p.refresh()
+	}
+
+	return &p
+}
+
+// refresh : redraws the tree every second so running times of a live build
+// stay current
+func (p *verboseprogress) refresh() {
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case now := <-t.C:
+			p.mu.Lock()
+			p.seen(now)
+			_ = p.draw()
+			p.mu.Unlock()
+		}
+	}
+}
+
+func (p *verboseprogress) build(s model.BuildEvent, at time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.seen(at)
+
+	switch s.Subject {
+	case BUILDCREATE, BUILDDELETE, BUILDIMPORT:
+		p.service = s
+		p.status = yellow(buildStatus(s.Subject))
+		for _, c := range s.Changes {
+			p.track(c.Type, c.Name).action = c.Action
+		}
+	case BUILDCREATEDONE, BUILDDELETEDONE, BUILDIMPORTDONE:
+		p.status = green(buildStatus(s.Subject))
+	case BUILDCREATEERROR, BUILDDELETEERROR, BUILDIMPORTERROR:
+		p.status = red("Error")
+	}
+
+	return p.draw()
+}
+
+func (p *verboseprogress) component(c model.ComponentEvent, at time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.seen(at)
+
+	cp := p.track(c.Type, c.Name)
+	cp.action = c.Action
+	cp.state = c.State
+
+	switch c.State {
+	case "running":
+		cp.started = at
+		cp.finished = time.Time{}
+	case "completed", "errored":
+		if cp.started.IsZero() {
+			cp.started = at
+		}
+		cp.finished = at
+		cp.err = c.Error
+	}
+
+	// components discovered by an import are listed as found
+	for _, found := range c.Components {
+		fc := p.track(found.Type, found.Name)
+		fc.action = "find"
+		fc.state = "completed"
+	}
+
+	return p.draw()
+}
+
+func (p *verboseprogress) polled(status string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch status {
+	case "done":
+		p.status = green("Done")
+	case "errored":
+		p.status = red("Error")
+	}
+
+	return p.draw()
+}
+
+func (p *verboseprogress) stop() {
+	if p.live {
+		close(p.done)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_ = p.draw()
+	p.writer.Stop()
+}
+
+// seen : moves the time running components are measured against
+func (p *verboseprogress) seen(at time.Time) {
+	if at.After(p.now) {
+		p.now = at
+	}
+}
+
+// track : returns the tracked state of a component, adding it if unknown
+func (p *verboseprogress) track(kind, name string) *componentprogress {
+	for _, c := range p.components[kind] {
+		if c.name == name {
+			return c
+		}
+	}
+
+	if _, ok := p.components[kind]; !ok {
+		p.types = append(p.types, kind)
+	}
+
+	c := componentprogress{kind: kind, name: name, state: "pending"}
+	p.components[kind] = append(p.components[kind], &c)
+
+	return &c
+}
+
+// draw : redraws the component tree
+func (p *verboseprogress) draw() error {
+	if p.service.Subject == "" {
+		return nil
+	}
+
+	var blue = color.New(color.FgBlue).SprintFunc()
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "\nEnvironment Name: %s\nBuild ID: %s\n\n", blue(p.service.Name), blue(p.service.ID))
+
+	if len(p.types) == 0 {
+		fmt.Fprintln(&b, green("No changes detected"))
+	}
+
+	longest, longestType := 0, 0
+	for kind, components := range p.components {
+		if len(formatType(kind))+1 > longestType {
+			longestType = len(formatType(kind)) + 1
+		}
+		for _, c := range components {
+			if len(c.name) > longest {
+				longest = len(c.name)
+			}
+		}
+	}
+
+	for _, kind := range p.types {
+		components := p.components[kind]
+
+		completed, running, errored := 0, 0, 0
+		for _, c := range components {
+			switch c.state {
+			case "completed":
+				completed++
+			case "running":
+				running++
+			case "errored":
+				errored++
+			}
+		}
+
+		var state string
+		switch {
+		case errored > 0:
+			state = red("Error")
+		case completed == len(components):
+			state = green(actionLabel(components[0].action, "completed"))
+		case running > 0:
+			state = yellow(actionLabel(components[0].action, "running"))
+		default:
+			state = "Pending"
+		}
+
+		fmt.Fprintf(&b, "%-"+strconv.Itoa(longestType)+"s %3d/%-3d %s\n", formatType(kind)+"s", completed, len(components), state)
+
+		// finished groups are collapsed into their summary line
+		if completed == len(components) {
+			continue
+		}
+
+		for _, c := range components {
+			fmt.Fprintf(&b, "  %-"+strconv.Itoa(longest)+"s  %-7s %-10s %s\n", c.name, c.action, c.state, formatElapsed(c.elapsed(p.now)))
+			if c.err != "" {
+				fmt.Fprintf(&b, "    %s\n", red("Error: "+c.err))
+			}
+		}
+	}
+
+	fmt.Fprintf(&b, "\nStatus: %s\n\n", p.status)
+
+	if _, err := p.writer.Write(b.Bytes()); err != nil {
+		return err
+	}
+
+	return p.writer.Flush()
+}
+
+// actionLabel : describes a component action on the given state
+func actionLabel(action, state string) string {
+	labels := map[string][]string{
+		"create": {"Creating", "Created"},
+		"update": {"Updating", "Updated"},
+		"delete": {"Deleting", "Deleted"},
+		"find":   {"Searching", "Found"},
+	}
+
+	l, ok := labels[action]
+	if !ok {
+		return formatType(state)
+	}
+	if state == "completed" {
+		return l[1]
+	}
+	return l[0]
+}
+
+// buildStatus : describes the overall status of a build given its subject
+func buildStatus(subject string) string {
+	switch subject {
+	case BUILDCREATE:
+		return "Applying"
+	case BUILDDELETE:
+		return "Destroying"
+	case BUILDIMPORT:
+		return "Importing"
+	case BUILDCREATEDONE:
+		return "Applied"
+	case BUILDDELETEDONE:
+		return "Destroyed"
+	case BUILDIMPORTDONE:
+		return "Imported"
+	}
+	return "Unknown"
+}
+
+// formatElapsed : formats a running time as minutes and seconds
+func formatElapsed(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	d = d / time.Second * time.Second
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
@@ -0,0 +1,32 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/ernestio/ernest-cli/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestVerboseProgressReplay(t *testing.T) {
+	Convey("Given a verbose progress replaying a build", t, func() {
+		p := newVerboseProgress(ioutil.Discard, false)
+		defer p.stop()
+
+		at := time.Date(2017, 3, 1, 10, 0, 0, 0, time.UTC)
+		_ = p.build(model.BuildEvent{ID: "1", Name: "env", Subject: BUILDCREATE}, at)
+		_ = p.component(model.ComponentEvent{Type: "instance", Name: "web-1", Action: "create", State: "running"}, at.Add(time.Second))
+		_ = p.component(model.ComponentEvent{Type: "network", Name: "net", Action: "create", State: "running"}, at.Add(2*time.Second))
+		_ = p.component(model.ComponentEvent{Type: "network", Name: "net", Action: "create", State: "completed"}, at.Add(5*time.Second))
+
+		Convey("It should time running components by the latest event", func() {
+			So(p.track("instance", "web-1").elapsed(p.now), ShouldEqual, 4*time.Second)
+			So(p.track("network", "net").elapsed(p.now), ShouldEqual, 3*time.Second)
+		})
+	})
+}
@@ -0,0 +1,110 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/r3labs/sse"
+)
+
+// RecordedEvent is a raw build stream event as stored on a recording.
+// Recordings are json lines files, one event per line
+type RecordedEvent struct {
+	Time time.Time       `json:"time"`
+	ID   string          `json:"id,omitempty"`
+	Data json.RawMessage `json:"data"`
+}
+
+// recorder writes the raw events of a build stream to a file
+type recorder struct {
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func newRecorder(path string) (*recorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, errors.New("Can't create recording file " + path)
+	}
+
+	return &recorder{file: f, encoder: json.NewEncoder(f)}, nil
+}
+
+// record : stores an event with the time it was received at
+func (r *recorder) record(msg *sse.Event, data []byte, at time.Time) error {
+	return r.encoder.Encode(RecordedEvent{
+		Time: at,
+		ID:   string(msg.ID),
+		Data: json.RawMessage(data),
+	})
+}
+
+func (r *recorder) close() error {
+	return r.file.Close()
+}
+
+// ReadRecording : loads the events stored on a recording file
+func ReadRecording(path string) ([]RecordedEvent, error) {
+	var events []RecordedEvent
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.New("Can't access recording file " + path)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var e RecordedEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, errors.New("Invalid recording event on line " + strconv.Itoa(line) + ": " + err.Error())
+		}
+		events = append(events, e)
+	}
+
+	return events, scanner.Err()
+}
+
+// Replay : renders a recorded build stream as it was received. Speed
+// accelerates the replay, a speed of zero replays it without waiting
+func Replay(events []RecordedEvent, speed float64, opts MonitorOptions) error {
+	h := buildhandler{
+		output: newBuildOutput(opts),
+	}
+	defer h.output.stop()
+
+	return writeReport(opts.Report, h.replay(events, speed))
+}
+
+func (h *buildhandler) replay(events []RecordedEvent, speed float64) error {
+	for i, e := range events {
+		if speed > 0 && i > 0 {
+			time.Sleep(time.Duration(float64(e.Time.Sub(events[i-1].Time)) / speed))
+		}
+
+		done, err := h.handle(&sse.Event{ID: []byte(e.ID), Data: e.Data}, e.Time)
+		if done || err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
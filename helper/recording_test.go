@@ -0,0 +1,45 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReplay(t *testing.T) {
+	Convey("Given a recorded build", t, func() {
+		events, err := ReadRecording("../internal/recordings/apply-errored.events")
+		So(err, ShouldBeNil)
+		So(len(events), ShouldEqual, 8)
+
+		Convey("When I replay it through the json progress renderer", func() {
+			var out bytes.Buffer
+			h := buildhandler{output: newJSONProgress(&out)}
+			err := h.replay(events, 0)
+
+			Convey("It should render every event and a summary", func() {
+				So(err, ShouldEqual, errBuildFailed)
+
+				lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+				So(len(lines), ShouldEqual, 9)
+
+				var s summaryrecord
+				So(json.Unmarshal([]byte(lines[8]), &s), ShouldBeNil)
+				So(s.Type, ShouldEqual, "summary")
+				So(s.Status, ShouldEqual, "errored")
+				So(s.Duration, ShouldEqual, 126)
+				So(s.Components, ShouldEqual, 3)
+				So(s.Completed, ShouldEqual, 2)
+				So(s.Errored, ShouldEqual, 1)
+				So(s.Errors, ShouldResemble, []string{"InsufficientInstanceCapacity"})
+			})
+		})
+	})
+}
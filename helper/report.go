@@ -0,0 +1,387 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ernestio/ernest-cli/model"
+)
+
+// Report formats
+const (
+	REPORTJUNIT = "junit"
+	REPORTTAP   = "tap"
+)
+
+// ReportFormats lists the accepted build report formats
+var ReportFormats = []string{REPORTJUNIT, REPORTTAP}
+
+// Report : collects the outcome of the builds followed by a command as test
+// results, every environment being a suite and every component a test case
+type Report struct {
+	// mu guards the suites of builds followed at the same time
+	mu      sync.Mutex
+	targets []reporttarget
+	suites  []*reportsuite
+}
+
+type reporttarget struct {
+	format string
+	path   string
+}
+
+// NewReport : creates a report from a list of format=file specifications
+func NewReport(specs []string) (*Report, error) {
+	r := Report{}
+
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, errors.New("Invalid report '" + spec + "', reports should be specified as format=file")
+		}
+
+		if parts[0] != REPORTJUNIT && parts[0] != REPORTTAP {
+			return nil, errors.New("Invalid report format '" + parts[0] + "', valid formats are " + strings.Join(ReportFormats, ", "))
+		}
+
+		r.targets = append(r.targets, reporttarget{format: parts[0], path: parts[1]})
+	}
+
+	return &r, nil
+}
+
+// suite : starts the suite of a build
+func (r *Report) suite(name string) *reportsuite {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := reportsuite{name: name, cases: make(map[string]*reportcase), mu: &r.mu}
+	r.suites = append(r.suites, &s)
+	return &s
+}
+
+// write : writes every report file with the suites collected so far
+func (r *Report) write() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range r.targets {
+		f, err := os.Create(t.path)
+		if err != nil {
+			return errors.New("Can't write report file " + t.path)
+		}
+
+		switch t.format {
+		case REPORTJUNIT:
+			err = r.writeJUnit(f)
+		case REPORTTAP:
+			err = r.writeTAP(f)
+		}
+
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reportsuite collects the results of the components of a build, rendering
+// its events as a progress output
+type reportsuite struct {
+	mu      *sync.Mutex
+	name    string
+	id      string
+	started time.Time
+	ended   time.Time
+	status  string
+	order   []string
+	cases   map[string]*reportcase
+}
+
+type reportcase struct {
+	kind    string
+	name    string
+	action  string
+	state   string
+	message string
+	started time.Time
+	ended   time.Time
+}
+
+func (c *reportcase) failed() bool {
+	return c.state != "completed"
+}
+
+func (c *reportcase) failure() string {
+	if c.message != "" {
+		return c.message
+	}
+	if c.state == "errored" {
+		return "component errored"
+	}
+	return "component did not finish"
+}
+
+func (s *reportsuite) build(e model.BuildEvent, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch e.Subject {
+	case BUILDCREATE, BUILDDELETE, BUILDIMPORT:
+		s.id = e.ID
+		s.started = at
+		if s.name == "" {
+			s.name = e.Name
+		}
+	case BUILDCREATEDONE, BUILDDELETEDONE, BUILDIMPORTDONE:
+		s.finish("done", at)
+	case BUILDCREATEERROR, BUILDDELETEERROR, BUILDIMPORTERROR:
+		s.finish("errored", at)
+	}
+
+	return nil
+}
+
+func (s *reportsuite) component(e model.ComponentEvent, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.cases[e.ID]
+	if !ok {
+		c = &reportcase{kind: e.Type, name: e.Name, action: e.Action, started: at}
+		s.cases[e.ID] = c
+		s.order = append(s.order, e.ID)
+	}
+
+	c.state = e.State
+	if e.State == "completed" || e.State == "errored" {
+		c.ended = at
+	}
+	if e.Error != "" {
+		c.message = e.Error
+	}
+
+	return nil
+}
+
+func (s *reportsuite) polled(status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.finish(status, time.Now())
+	return nil
+}
+
+func (s *reportsuite) stop() {}
+
+func (s *reportsuite) finish(status string, at time.Time) {
+	s.status = status
+	s.ended = at
+}
+
+func (s *reportsuite) duration() time.Duration {
+	if s.started.IsZero() || s.ended.IsZero() {
+		return 0
+	}
+	return s.ended.Sub(s.started)
+}
+
+// results : the test cases of the suite, failing the build itself when it
+// did not succeed without any of its components failing
+func (s *reportsuite) results() []*reportcase {
+	var cases []*reportcase
+	failed := false
+
+	for _, id := range s.order {
+		c := s.cases[id]
+		if c.ended.IsZero() {
+			c.ended = s.ended
+		}
+		if c.failed() {
+			failed = true
+		}
+		cases = append(cases, c)
+	}
+
+	if s.status != "done" && !failed {
+		message := "build errored"
+		if s.status == "" {
+			message = "build did not finish"
+		}
+		cases = append(cases, &reportcase{kind: "build", name: s.id, state: s.status, message: message, started: s.started, ended: s.ended})
+	}
+
+	return cases
+}
+
+type junitsuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitsuite `xml:"testsuite"`
+}
+
+type junitsuite struct {
+	Name      string      `xml:"name,attr"`
+	ID        string      `xml:"id,attr,omitempty"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Errors    int         `xml:"errors,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr,omitempty"`
+	Cases     []junitcase `xml:"testcase"`
+}
+
+type junitcase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitfailure `xml:"failure,omitempty"`
+}
+
+type junitfailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (r *Report) writeJUnit(w io.Writer) error {
+	var all junitsuites
+	var total time.Duration
+
+	for _, s := range r.suites {
+		js := junitsuite{
+			Name: s.name,
+			ID:   s.id,
+			Time: formatSeconds(s.duration()),
+		}
+		if !s.started.IsZero() {
+			js.Timestamp = s.started.UTC().Format("2006-01-02T15:04:05")
+		}
+
+		for _, c := range s.results() {
+			jc := junitcase{
+				ClassName: s.name + "." + c.kind,
+				Name:      caseName(c),
+				Time:      formatSeconds(c.ended.Sub(c.started)),
+			}
+			if c.failed() {
+				jc.Failure = &junitfailure{Message: c.failure(), Type: c.state, Text: c.failure()}
+				js.Failures++
+			}
+			js.Cases = append(js.Cases, jc)
+		}
+
+		js.Tests = len(js.Cases)
+		all.Tests += js.Tests
+		all.Failures += js.Failures
+		all.Suites = append(all.Suites, js)
+		total += s.duration()
+	}
+	all.Time = formatSeconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	if err := e.Encode(all); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func (r *Report) writeTAP(w io.Writer) error {
+	var lines []string
+	n := 0
+
+	for _, s := range r.suites {
+		lines = append(lines, "# "+s.name)
+		for _, c := range s.results() {
+			n++
+			line := fmt.Sprintf("%d - %s %s", n, s.name+"."+c.kind, caseName(c))
+			if !c.failed() {
+				lines = append(lines, "ok "+line)
+				continue
+			}
+			lines = append(lines, "not ok "+line)
+			lines = append(lines, "  ---", "  message: "+tapQuote(c.failure()), "  severity: "+c.state, "  ...")
+		}
+	}
+
+	out := "TAP version 13\n" + fmt.Sprintf("1..%d\n", n) + strings.Join(lines, "\n") + "\n"
+	_, err := io.WriteString(w, out)
+	return err
+}
+
+func caseName(c *reportcase) string {
+	if c.action == "" {
+		return c.name
+	}
+	return c.name + " (" + c.action + ")"
+}
+
+func formatSeconds(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+func tapQuote(s string) string {
+	return "'" + strings.Replace(strings.Replace(s, "\n", " ", -1), "'", "''", -1) + "'"
+}
+
+// teeprogress renders the events of a build on several progress outputs
+type teeprogress []progress
+
+func (t teeprogress) build(s model.BuildEvent, at time.Time) error {
+	for _, p := range t {
+		if err := p.build(s, at); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t teeprogress) component(c model.ComponentEvent, at time.Time) error {
+	for _, p := range t {
+		if err := p.component(c, at); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t teeprogress) polled(status string) error {
+	for _, p := range t {
+		if err := p.polled(status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t teeprogress) stop() {
+	for _, p := range t {
+		p.stop()
+	}
+}
@@ -0,0 +1,67 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReport(t *testing.T) {
+	Convey("Given a recorded build", t, func() {
+		events, err := ReadRecording("../internal/recordings/apply-errored.events")
+		So(err, ShouldBeNil)
+
+		Convey("When I replay it collecting a report", func() {
+			r, err := NewReport([]string{"junit=unused.xml"})
+			So(err, ShouldBeNil)
+
+			h := buildhandler{output: teeprogress{newJSONProgress(ioutil.Discard), r.suite("project/env")}}
+			So(h.replay(events, 0), ShouldEqual, errBuildFailed)
+
+			Convey("It should write every component as a junit test case", func() {
+				var out bytes.Buffer
+				So(r.writeJUnit(&out), ShouldBeNil)
+
+				var suites junitsuites
+				So(xml.Unmarshal(out.Bytes(), &suites), ShouldBeNil)
+				So(len(suites.Suites), ShouldEqual, 1)
+				So(suites.Tests, ShouldEqual, 3)
+				So(suites.Failures, ShouldEqual, 1)
+
+				s := suites.Suites[0]
+				So(s.Name, ShouldEqual, "project/env")
+				So(s.Time, ShouldEqual, "126.000")
+				So(s.Cases[2].ClassName, ShouldEqual, "project/env.instance")
+				So(s.Cases[2].Failure, ShouldNotBeNil)
+				So(s.Cases[2].Failure.Message, ShouldEqual, "InsufficientInstanceCapacity")
+			})
+
+			Convey("It should write every component as a tap test", func() {
+				var out bytes.Buffer
+				So(r.writeTAP(&out), ShouldBeNil)
+
+				lines := strings.Split(out.String(), "\n")
+				So(lines[0], ShouldEqual, "TAP version 13")
+				So(lines[1], ShouldEqual, "1..3")
+				So(out.String(), ShouldContainSubstring, "not ok 3 - project/env.instance web-2")
+				So(out.String(), ShouldContainSubstring, "message: 'InsufficientInstanceCapacity'")
+			})
+		})
+	})
+
+	Convey("Given an invalid report specification", t, func() {
+		_, err := NewReport([]string{"xunit=out.xml"})
+
+		Convey("It should fail", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,152 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Scaffold presets
+const (
+	PRESETWEBAPP  = "web-app"
+	PRESETVPCONLY = "vpc-only"
+)
+
+// ScaffoldProviders lists the providers definitions can be scaffolded for
+var ScaffoldProviders = []string{"aws", "azure", "vcloud"}
+
+// ScaffoldPresets lists the presets of the scaffolded definitions
+var ScaffoldPresets = []string{PRESETWEBAPP, PRESETVPCONLY}
+
+// ScaffoldOptions : the values of a scaffolded definition
+type ScaffoldOptions struct {
+	Provider string
+	Preset   string
+	Name     string
+	Project  string
+	// CIDR is the address space of the vpc or virtual network
+	CIDR string
+	// Subnet is the address range of the web network, inside the CIDR
+	Subnet string
+	Count  int
+	Dir    string
+}
+
+// ScaffoldFile : a file of a scaffolded definition
+type ScaffoldFile struct {
+	Path    string
+	Content string
+}
+
+// scaffoldData : the values the definition templates are rendered with
+type scaffoldData struct {
+	ScaffoldOptions
+	Path        string
+	StartIP     string
+	UserDataRef string
+}
+
+// Scaffold : renders a commented starter definition for a provider and
+// preset, with the user data template its instances reference
+func Scaffold(opts ScaffoldOptions) ([]ScaffoldFile, error) {
+	presets, ok := scaffoldTemplates[opts.Provider]
+	if !ok {
+		return nil, errors.New("Invalid provider '" + opts.Provider + "', valid providers are " + strings.Join(ScaffoldProviders, ", "))
+	}
+	definition, ok := presets[opts.Preset]
+	if !ok {
+		return nil, errors.New("Invalid preset '" + opts.Preset + "', valid presets are " + strings.Join(ScaffoldPresets, ", "))
+	}
+	if opts.Name == "" || opts.Project == "" {
+		return nil, errors.New("The definition needs an environment and a project name")
+	}
+	if opts.Count < 1 {
+		return nil, errors.New("The definition needs at least one instance")
+	}
+
+	startIP, err := scaffoldAddresses(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(opts.Dir, "ernest.yml")
+	data := scaffoldData{ScaffoldOptions: opts, Path: path, StartIP: startIP}
+	usesUserData := strings.Contains(definition, ".UserDataRef")
+	if usesUserData {
+		data.UserDataRef = "@{user-data.yml}"
+	}
+
+	content, err := renderScaffold(definition, data)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []ScaffoldFile{{Path: path, Content: content}}
+	if usesUserData {
+		files = append(files, ScaffoldFile{Path: filepath.Join(opts.Dir, "user-data.yml"), Content: userDataTemplate})
+	}
+
+	return files, nil
+}
+
+// scaffoldAddresses : validates the network ranges, returning the first ip
+// of the instances, leaving the first ones of the subnet to the provider
+func scaffoldAddresses(opts ScaffoldOptions) (string, error) {
+	_, network, err := net.ParseCIDR(opts.CIDR)
+	if err != nil {
+		return "", errors.New("Invalid network range '" + opts.CIDR + "', it should be a cidr like 10.0.0.0/16")
+	}
+	_, subnet, err := net.ParseCIDR(opts.Subnet)
+	if err != nil || subnet.IP.To4() == nil {
+		return "", errors.New("Invalid subnet '" + opts.Subnet + "', it should be an ipv4 cidr like 10.0.1.0/24")
+	}
+
+	nOnes, _ := network.Mask.Size()
+	sOnes, _ := subnet.Mask.Size()
+	if opts.Provider != "vcloud" && (!network.Contains(subnet.IP) || sOnes < nOnes) {
+		return "", errors.New("The subnet " + opts.Subnet + " should be inside the network range " + opts.CIDR)
+	}
+
+	first := binary.BigEndian.Uint32(subnet.IP.To4()) + 11
+	last := first + uint32(opts.Count) - 1
+	_, bits := subnet.Mask.Size()
+	size := uint32(1) << uint(bits-sOnes)
+	if uint64(last) >= uint64(binary.BigEndian.Uint32(subnet.IP.To4()))+uint64(size)-1 {
+		return "", errors.New("The subnet " + opts.Subnet + " is too small for " + plural(opts.Count, "instance"))
+	}
+
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, first)
+
+	return ip.String(), nil
+}
+
+func renderScaffold(text string, data scaffoldData) (string, error) {
+	t, err := template.New("definition").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return "1 " + word
+	}
+	return strconv.Itoa(n) + " " + word + "s"
+}
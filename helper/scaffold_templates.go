@@ -0,0 +1,236 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+// scaffoldTemplates are the definition templates by provider and preset
+var scaffoldTemplates = map[string]map[string]string{
+	"aws": {
+		PRESETWEBAPP:  definitionHeader + awsNetwork + awsWebApp,
+		PRESETVPCONLY: definitionHeader + awsNetwork,
+	},
+	"azure": {
+		PRESETWEBAPP:  definitionHeader + azureNetwork + azureWebApp,
+		PRESETVPCONLY: definitionHeader + azureNetwork,
+	},
+	"vcloud": {
+		PRESETWEBAPP:  definitionHeader + vcloudNetwork + vcloudWebApp,
+		PRESETVPCONLY: definitionHeader + vcloudNetwork,
+	},
+}
+
+const definitionHeader = `# {{.Name}}, an environment of the {{.Project}} project.
+#
+# Generated by 'ernest env init' with the {{.Preset}} preset. Review the values
+# marked as CHANGE ME, then check what applying it would create with:
+#
+#   ernest env apply --dry {{.Path}}
+#
+name: {{.Name}}
+project: {{.Project}}
+`
+
+const awsNetwork = `
+# The private network every component of the environment is created on.
+vpcs:
+  - name: {{.Name}}-vpc
+    subnet: {{.CIDR}}
+    # remove the vpc when the environment is destroyed
+    auto_remove: true
+
+# The subnets of the vpc. Public ones are routed to an internet gateway, so
+# their instances can get public ips.
+networks:
+  - name: web
+    vpc: {{.Name}}-vpc
+    subnet: {{.Subnet}}
+    public: true
+`
+
+const awsWebApp = `
+# Firewall rules, referenced by name from instances and load balancers.
+security_groups:
+  - name: web-sg
+    vpc: {{.Name}}-vpc
+    ingress:
+      # http from anywhere
+      - ip: 0.0.0.0/0
+        protocol: tcp
+        from_port: '80'
+        to_port: '80'
+      # ssh from inside the vpc only
+      - ip: {{.CIDR}}
+        protocol: tcp
+        from_port: '22'
+        to_port: '22'
+    egress:
+      - ip: 0.0.0.0/0
+        protocol: any
+        from_port: '0'
+        to_port: '65535'
+
+# Instances are named web-1 to web-{{.Count}}, with consecutive ips from start_ip.
+instances:
+  - name: web
+    type: t2.micro
+    # CHANGE ME: an AMI available on the region of the project
+    image: ami-00000000
+    count: {{.Count}}
+    network: web
+    start_ip: {{.StartIP}}
+    # CHANGE ME: a key pair created on the region of the project
+    key_pair: {{.Name}}-key
+    security_groups:
+      - web-sg
+    # runs on the first boot of every instance
+    user_data: '{{.UserDataRef}}'
+
+# A load balancer spreading http traffic across the web instances.
+elbs:
+  - name: {{.Name}}-elb
+    private: false
+    subnets:
+      - web
+    instances:
+      - web
+    security_groups:
+      - web-sg
+    listeners:
+      - from_port: 80
+        to_port: 80
+        protocol: HTTP
+`
+
+const azureNetwork = `
+# Azure resources belong to resource groups, created on a location.
+resource_groups:
+  - name: {{.Name}}-rg
+    # CHANGE ME: the location of the environment
+    location: westeurope
+
+    # The private network every component of the environment is created on.
+    virtual_networks:
+      - name: {{.Name}}-vnet
+        address_space:
+          - {{.CIDR}}
+        subnets:
+          - name: web
+            address_prefix: {{.Subnet}}
+`
+
+const azureWebApp = `            security_group: web-sg
+
+    # Firewall rules, referenced by name from subnets.
+    security_groups:
+      - name: web-sg
+        rules:
+          # http from anywhere
+          - name: http
+            priority: 100
+            direction: Inbound
+            access: Allow
+            protocol: Tcp
+            source_port_range: '*'
+            destination_port_range: '80'
+            source_address_prefix: '*'
+            destination_address_prefix: '*'
+          # ssh from inside the virtual network only
+          - name: ssh
+            priority: 110
+            direction: Inbound
+            access: Allow
+            protocol: Tcp
+            source_port_range: '*'
+            destination_port_range: '22'
+            source_address_prefix: {{.CIDR}}
+            destination_address_prefix: '*'
+
+    # Virtual machines are named web-1 to web-{{.Count}}.
+    virtual_machines:
+      - name: web
+        size: Standard_DS1_v2
+        count: {{.Count}}
+        image: Canonical:UbuntuServer:16.04-LTS:latest
+        authentication:
+          admin_username: ubuntu
+          ssh_keys:
+            - path: /home/ubuntu/.ssh/authorized_keys
+              # CHANGE ME: the public key allowed to log in
+              key_data: ssh-rsa AAAA
+        network_interfaces:
+          - name: web-nic
+            ip_configurations:
+              - name: web-ip
+                subnet: {{.Name}}-vnet:web
+                private_ip_address_allocation: dynamic
+        storage_os_disk:
+          name: web-os-disk
+          caching: ReadWrite
+          create_option: FromImage
+          managed_disk_type: Standard_LRS
+        # runs on the first boot of every virtual machine
+        custom_data: '{{.UserDataRef}}'
+`
+
+const vcloudNetwork = `
+# CHANGE ME: the edge gateway of the project routing the environment networks
+router: {{.Name}}-router
+
+# The networks of the environment, attached to the router.
+networks:
+  - name: web
+    subnet: {{.Subnet}}
+    dns:
+      - 8.8.8.8
+      - 8.8.4.4
+`
+
+const vcloudWebApp = `
+# Instances are named web-1 to web-{{.Count}}, with consecutive ips from start_ip.
+instances:
+  - name: web
+    # CHANGE ME: a catalog/image available on the project
+    image: images/ubuntu-1604
+    cpus: 1
+    memory: 1GB
+    count: {{.Count}}
+    networks:
+      name: web
+      start_ip: {{.StartIP}}
+
+# Firewall rules of the router.
+firewalls:
+  - name: {{.Name}}-router
+    rules:
+      # http from anywhere to the web network
+      - name: http
+        source_ip: any
+        source_port: any
+        destination_ip: {{.Subnet}}
+        destination_port: '80'
+        protocol: tcp
+      # outbound traffic from the web network
+      - name: outbound
+        source_ip: {{.Subnet}}
+        source_port: any
+        destination_ip: any
+        destination_port: any
+        protocol: any
+`
+
+// userDataTemplate is the cloud-init template instances run on their first boot
+const userDataTemplate = `#cloud-config
+#
+# Runs on the first boot of every instance referencing this file with @{...}.
+# See https://cloudinit.readthedocs.io for every option.
+
+package_update: true
+
+packages:
+  - nginx
+
+runcmd:
+  - systemctl enable nginx
+  - systemctl start nginx
+`
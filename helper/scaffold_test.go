@@ -0,0 +1,61 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"testing"
+
+	"github.com/ernestio/ernest-cli/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestScaffold(t *testing.T) {
+	Convey("Given the scaffolding options of every provider and preset", t, func() {
+		for _, provider := range ScaffoldProviders {
+			for _, preset := range ScaffoldPresets {
+				opts := ScaffoldOptions{Provider: provider, Preset: preset, Name: "web", Project: "shop", CIDR: "10.0.0.0/16", Subnet: "10.0.1.0/24", Count: 2, Dir: "infra"}
+
+				Convey("It should render a valid "+provider+" "+preset+" definition", func() {
+					files, err := Scaffold(opts)
+					So(err, ShouldBeNil)
+					So(files[0].Path, ShouldEqual, "infra/ernest.yml")
+
+					var d model.Definition
+					So(d.Load([]byte(files[0].Content)), ShouldBeNil)
+					So(d.Validate(), ShouldBeNil)
+					So(d.Name, ShouldEqual, "web")
+
+					if len(files) > 1 {
+						So(files[0].Content, ShouldContainSubstring, "@{user-data.yml}")
+						So(d.FileImports(), ShouldResemble, []string{"user-data.yml"})
+						So(files[1].Path, ShouldEqual, "infra/user-data.yml")
+
+						d.ResolveFileImports("infra")
+						So(d.FileImports(), ShouldResemble, []string{"infra/user-data.yml"})
+					}
+				})
+			}
+		}
+	})
+
+	Convey("Given a subnet too small for the instances", t, func() {
+		opts := ScaffoldOptions{Provider: "aws", Preset: PRESETWEBAPP, Name: "web", Project: "shop", CIDR: "10.0.0.0/16", Subnet: "10.0.1.0/28", Count: 5}
+
+		Convey("It should fail to render the definition", func() {
+			_, err := Scaffold(opts)
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given enough room on the subnet", t, func() {
+		opts := ScaffoldOptions{Provider: "aws", Preset: PRESETWEBAPP, Name: "web", Project: "shop", CIDR: "10.0.0.0/16", Subnet: "10.0.1.0/24", Count: 2}
+
+		Convey("It should start the instance ips after the ones of the provider", func() {
+			ip, err := scaffoldAddresses(opts)
+			So(err, ShouldBeNil)
+			So(ip, ShouldEqual, "10.0.1.11")
+		})
+	})
+}
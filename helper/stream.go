@@ -0,0 +1,138 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/r3labs/sse"
+	backoff "gopkg.in/cenkalti/backoff.v1"
+)
+
+// maxReconnectInterval is the longest wait between two reconnection attempts
+const maxReconnectInterval = 15 * time.Second
+
+// eventstream is a subscription to an sse stream that survives connection
+// drops. On reconnection it resumes from the last received event, and
+// events that were already delivered are not delivered again
+type eventstream struct {
+	events chan *sse.Event
+	errors chan error
+	cancel context.CancelFunc
+	seen   map[string]bool
+	// last is the id of the last delivered event
+	last string
+	// resumed is true from a reconnection until an event is delivered
+	resumed bool
+}
+
+// subscribe : subscribes to an sse stream
+func subscribe(host, endpoint, token, stream string) *eventstream {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := eventstream{
+		events: make(chan *sse.Event, 1024),
+		errors: make(chan error, 1),
+		cancel: cancel,
+		seen:   make(map[string]bool),
+	}
+
+	client := sse.NewClient(host + endpoint)
+	client.EncodingBase64 = true
+	client.Connection.Transport = &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+	client.Headers["Authorization"] = fmt.Sprintf("Bearer %s", token)
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxInterval = maxReconnectInterval
+	b.MaxElapsedTime = 0
+	client.ReconnectStrategy = backoff.WithContext(b, ctx)
+	client.ReconnectNotify = func(err error, next time.Duration) {
+		s.resumed = true
+		if ctx.Err() == nil {
+			fmt.Fprintf(os.Stderr, "Connection to ernest lost (%s), reconnecting in %s\n", err.Error(), next.Round(time.Second))
+		}
+	}
+
+	go func() {
+		closed := backoff.NewExponentialBackOff()
+		closed.MaxInterval = maxReconnectInterval
+		closed.MaxElapsedTime = 0
+
+		for {
+			err := client.SubscribeWithContext(ctx, stream, func(msg *sse.Event) {
+				closed.Reset()
+				if s.accept(msg) {
+					s.events <- msg
+				}
+			})
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				s.errors <- err
+				return
+			}
+
+			// the server closed the connection, the client resumes it from
+			// the last event it received
+			next := closed.NextBackOff()
+			fmt.Fprintf(os.Stderr, "Connection to ernest closed, reconnecting in %s\n", next.Round(time.Second))
+			s.resumed = true
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(next):
+			}
+		}
+	}()
+
+	return &s
+}
+
+// accept : returns true if an event wasn't delivered already. The client
+// gives the events without an id the id of the previous one, so an event
+// with the id of the last delivered one is only a repetition when it's
+// replayed after a reconnection
+func (s *eventstream) accept(msg *sse.Event) bool {
+	id := string(msg.ID)
+	if id == "" || (id == s.last && !s.resumed) {
+		s.resumed = false
+		return true
+	}
+	if s.seen[id] {
+		return false
+	}
+
+	s.seen[id] = true
+	s.last = id
+	s.resumed = false
+
+	return true
+}
+
+// close : stops the subscription
+func (s *eventstream) close() {
+	s.cancel()
+}
+
+// OpenStream : opens an sse stream
+func OpenStream(host, endpoint, token, stream string) chan *sse.Event {
+	s := subscribe(host, endpoint, token, stream)
+
+	go func() {
+		err := <-s.errors
+		fmt.Println("error connecting to stream: " + err.Error())
+		os.Exit(1)
+	}()
+
+	return s.events
+}
@@ -0,0 +1,79 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestEventStream(t *testing.T) {
+	Convey("Given a server closing the stream after some events", t, func() {
+		var mu sync.Mutex
+		var lastEventIDs []string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+			connection := len(lastEventIDs)
+			mu.Unlock()
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			send := func(id, data string) {
+				if id != "" {
+					fmt.Fprintf(w, "id: %s\n", id)
+				}
+				fmt.Fprintf(w, "data: %s\n\n", base64.StdEncoding.EncodeToString([]byte(data)))
+				w.(http.Flusher).Flush()
+			}
+
+			if connection == 1 {
+				send("1", "a")
+				send("", "b")
+				send("2", "c")
+				return
+			}
+
+			// the last event is sent again before the new ones
+			send("2", "c")
+			send("3", "d")
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		Convey("When I subscribe to it", func() {
+			s := subscribe(server.URL, "/events", "token", "build")
+			defer s.close()
+
+			var data []string
+			timeout := time.After(10 * time.Second)
+			for len(data) < 4 {
+				select {
+				case msg := <-s.events:
+					data = append(data, string(msg.Data))
+				case err := <-s.errors:
+					So(err, ShouldBeNil)
+				case <-timeout:
+					t.Fatal("timed out waiting for events")
+				}
+			}
+
+			Convey("It should resume the stream from the last event once closed", func() {
+				So(data, ShouldResemble, []string{"a", "b", "c", "d"})
+
+				mu.Lock()
+				defer mu.Unlock()
+				So(lastEventIDs, ShouldResemble, []string{"", "2"})
+			})
+		})
+	})
+}
@@ -0,0 +1,192 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/ernestio/ernest-cli/model"
+)
+
+// ComponentTiming is the time a component spent on running state
+type ComponentTiming struct {
+	Type     string
+	Name     string
+	Action   string
+	State    string
+	Start    time.Time
+	End      time.Time
+	Critical bool
+}
+
+// Duration : time the component spent running
+func (c *ComponentTiming) Duration() time.Duration {
+	if c.Start.IsZero() || c.End.IsZero() {
+		return 0
+	}
+	return c.End.Sub(c.Start)
+}
+
+// TypeTiming aggregates the running times of a component type
+type TypeTiming struct {
+	Type    string
+	Count   int
+	Total   time.Duration
+	Slowest time.Duration
+}
+
+// BuildTimings are the running times of a build and its components
+type BuildTimings struct {
+	ID         string
+	Name       string
+	Status     string
+	Start      time.Time
+	End        time.Time
+	Components []*ComponentTiming
+	Types      []TypeTiming
+}
+
+// Duration : build wall time
+func (b *BuildTimings) Duration() time.Duration {
+	return b.End.Sub(b.Start)
+}
+
+// CriticalPath : returns the components on the critical path, sorted by start time
+func (b *BuildTimings) CriticalPath() []*ComponentTiming {
+	var path []*ComponentTiming
+	for _, c := range b.Components {
+		if c.Critical {
+			path = append(path, c)
+		}
+	}
+	return path
+}
+
+// BuildTimingsFromEvents : computes the build timings from its recorded events.
+// A component starts on its running event and ends when it is completed or
+// errored
+func BuildTimingsFromEvents(events []RecordedEvent) (*BuildTimings, error) {
+	var b BuildTimings
+
+	tracked := make(map[string]*ComponentTiming)
+
+	for _, e := range events {
+		m := make(map[string]interface{})
+		if err := json.Unmarshal(bytes.Trim(e.Data, "\x00"), &m); err != nil {
+			return nil, err
+		}
+
+		subject, _ := m["_subject"].(string)
+
+		switch subject {
+		case BUILDCREATE, BUILDDELETE, BUILDIMPORT:
+			s := processBuildEvent(m)
+			b.ID, b.Name, b.Start, b.Status = s.ID, s.Name, e.Time, "in_progress"
+		case BUILDCREATEDONE, BUILDDELETEDONE, BUILDIMPORTDONE:
+			b.End, b.Status = e.Time, "done"
+		case BUILDCREATEERROR, BUILDDELETEERROR, BUILDIMPORTERROR:
+			b.End, b.Status = e.Time, "errored"
+		default:
+			timeComponent(&b, tracked, processComponentEvent(m), e.Time)
+		}
+	}
+
+	if b.Start.IsZero() {
+		return nil, errors.New("The recorded events don't include the start of the build")
+	}
+
+	if b.End.IsZero() && len(events) > 0 {
+		b.End = events[len(events)-1].Time
+	}
+
+	markCriticalPath(b.Components)
+	b.Types = typeTimings(b.Components)
+
+	return &b, nil
+}
+
+func timeComponent(b *BuildTimings, tracked map[string]*ComponentTiming, c model.ComponentEvent, at time.Time) {
+	key := c.Type + "::" + c.Name
+
+	ct, ok := tracked[key]
+	if !ok {
+		ct = &ComponentTiming{Type: c.Type, Name: c.Name}
+		tracked[key] = ct
+		b.Components = append(b.Components, ct)
+	}
+
+	ct.Action = c.Action
+	ct.State = c.State
+
+	switch c.State {
+	case "running":
+		ct.Start = at
+		ct.End = time.Time{}
+	case "completed", "errored":
+		if ct.Start.IsZero() {
+			ct.Start = at
+		}
+		ct.End = at
+	}
+}
+
+// markCriticalPath : marks the chain of components that determined the
+// build wall time. Starting from the last component to finish, each step
+// goes back to the component that finished last before it started
+func markCriticalPath(components []*ComponentTiming) {
+	var last *ComponentTiming
+	for _, c := range components {
+		if !c.End.IsZero() && (last == nil || c.End.After(last.End)) {
+			last = c
+		}
+	}
+
+	for last != nil {
+		last.Critical = true
+
+		var previous *ComponentTiming
+		for _, c := range components {
+			if c.Critical || c.End.IsZero() || c.End.After(last.Start) {
+				continue
+			}
+			if previous == nil || c.End.After(previous.End) {
+				previous = c
+			}
+		}
+		last = previous
+	}
+}
+
+// typeTimings : aggregates component timings per type, slowest types first
+func typeTimings(components []*ComponentTiming) []TypeTiming {
+	var types []TypeTiming
+	index := make(map[string]int)
+
+	for _, c := range components {
+		i, ok := index[c.Type]
+		if !ok {
+			i = len(types)
+			index[c.Type] = i
+			types = append(types, TypeTiming{Type: c.Type})
+		}
+
+		d := c.Duration()
+		types[i].Count++
+		types[i].Total += d
+		if d > types[i].Slowest {
+			types[i].Slowest = d
+		}
+	}
+
+	sort.SliceStable(types, func(i, j int) bool {
+		return types[i].Total > types[j].Total
+	})
+
+	return types
+}
@@ -0,0 +1,41 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildTimingsFromEvents(t *testing.T) {
+	Convey("Given a recorded build", t, func() {
+		events, err := ReadRecording("../internal/recordings/apply-errored.events")
+		So(err, ShouldBeNil)
+
+		Convey("When I compute its timings", func() {
+			timings, err := BuildTimingsFromEvents(events)
+			So(err, ShouldBeNil)
+
+			Convey("It should time every component", func() {
+				So(timings.Status, ShouldEqual, "errored")
+				So(timings.Duration(), ShouldEqual, 126*time.Second)
+				So(len(timings.Components), ShouldEqual, 3)
+			})
+
+			Convey("It should find the critical path", func() {
+				path := timings.CriticalPath()
+				So(len(path), ShouldBeGreaterThan, 0)
+				So(path[len(path)-1].Name, ShouldEqual, "web-2")
+			})
+
+			Convey("It should rank the slowest types first", func() {
+				So(timings.Types[0].Type, ShouldEqual, "instance")
+				So(timings.Types[0].Count, ShouldEqual, 2)
+			})
+		})
+	})
+}
@@ -0,0 +1,76 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"os"
+	"time"
+)
+
+// FileWatcher : polls a set of files for changes, polling works the same
+// on every platform and with editors replacing files on save
+type FileWatcher struct {
+	Interval time.Duration
+	Debounce time.Duration
+	states   map[string]filestate
+}
+
+type filestate struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+// NewFileWatcher : creates a watcher polling files every interval, waiting
+// for them to settle for the debounce period before reporting a change
+func NewFileWatcher(interval, debounce time.Duration) *FileWatcher {
+	return &FileWatcher{Interval: interval, Debounce: debounce, states: make(map[string]filestate)}
+}
+
+// Watch : sets the files to watch, taking their current state as unchanged
+func (w *FileWatcher) Watch(files []string) {
+	w.states = make(map[string]filestate)
+	for _, f := range files {
+		w.states[f] = statFile(f)
+	}
+}
+
+// Changed : returns true when any watched file was created, removed or
+// modified since the last time it was checked
+func (w *FileWatcher) Changed() bool {
+	changed := false
+	for f, s := range w.states {
+		current := statFile(f)
+		if current != s {
+			w.states[f] = current
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Wait : blocks until a watched file changes and no more changes happen
+// for the debounce period
+func (w *FileWatcher) Wait() {
+	for !w.Changed() {
+		time.Sleep(w.Interval)
+	}
+
+	settled := time.Now()
+	for time.Since(settled) < w.Debounce {
+		time.Sleep(w.Interval)
+		if w.Changed() {
+			settled = time.Now()
+		}
+	}
+}
+
+func statFile(path string) filestate {
+	info, err := os.Stat(path)
+	if err != nil {
+		return filestate{}
+	}
+	return filestate{exists: true, size: info.Size(), modTime: info.ModTime()}
+}
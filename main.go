@@ -5,10 +5,10 @@
 package main
 
 import (
-	"log"
 	"os"
 
 	"github.com/ernestio/ernest-cli/command"
+	h "github.com/ernestio/ernest-cli/helper"
 	"github.com/urfave/cli"
 )
 
@@ -36,8 +36,26 @@ func main() {
 		command.CmdUsage,
 		command.CmdNotification,
 		command.CmdRoles,
+		command.CmdReplay,
+		command.CmdConvert,
 	}
+	handleUsageErrors(app.Commands)
+
 	if err := app.Run(os.Args); err != nil {
-		log.Println("Oops, something is broken")
+		h.Fail(err)
+	}
+}
+
+// handleUsageErrors : makes invalid flags exit with the usage exit code
+func handleUsageErrors(commands []cli.Command) {
+	for i := range commands {
+		commands[i].OnUsageError = usageError
+		handleUsageErrors(commands[i].Subcommands)
 	}
 }
+
+func usageError(c *cli.Context, err error, isSubcommand bool) error {
+	_ = cli.ShowCommandHelp(c, c.Command.Name)
+
+	return h.NewError(h.ExitUsage, "Incorrect Usage: "+err.Error())
+}
@@ -9,7 +9,8 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"strconv"
+	"path/filepath"
+	"sort"
 
 	"github.com/ernestio/ernest-cli/helper"
 	"github.com/ernestio/ernest-cli/model"
@@ -24,10 +25,10 @@ func (m *Manager) ListBuilds(project, env, token string) (builds []model.Build,
 			return nil, ErrConnectionRefused
 		}
 		if resp.StatusCode == 403 {
-			return builds, errors.New("You don't have permissions to perform this action")
+			return builds, helper.NewStatusError(403, "You don't have permissions to perform this action")
 		}
 		if resp.StatusCode == 404 {
-			return builds, errors.New("Specified environment name does not exist")
+			return builds, helper.NewStatusError(404, "Specified environment name does not exist")
 		}
 		return nil, err
 	}
@@ -38,9 +39,9 @@ func (m *Manager) ListBuilds(project, env, token string) (builds []model.Build,
 	return builds, err
 }
 
-// BuildStatus ...
-func (m *Manager) BuildStatus(token, project, env, index string) (build model.Build, err error) {
-	buildID, err := m.BuildIDFromIndex(token, project, env, index)
+// BuildStatus : gets the status of a build given a build reference
+func (m *Manager) BuildStatus(token, project, env, ref string) (build model.Build, err error) {
+	buildID, err := m.ResolveBuildID(token, project, env, ref)
 	if err != nil {
 		return build, err
 	}
@@ -48,15 +49,22 @@ func (m *Manager) BuildStatus(token, project, env, index string) (build model.Bu
 	return m.BuildStatusByID(token, project, env, buildID)
 }
 
-// BuildIDFromIndex ...
-func (m *Manager) BuildIDFromIndex(token, project, env, index string) (string, error) {
-	builds, _ := m.ListBuilds(project, env, token)
-	num, _ := strconv.Atoi(index)
-	if num < 1 || num > len(builds) {
-		return "", errors.New("Invalid build ID")
+// ResolveBuildID : resolves a build reference to the ID of one of the
+// environment builds. Accepted references are a numeric index counting
+// from the oldest build, a full or short build ID, latest, previous,
+// latest~N and @<date> for the build that was current at that time
+func (m *Manager) ResolveBuildID(token, project, env, ref string) (string, error) {
+	builds, err := m.ListBuilds(project, env, token)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := resolveBuildRef(builds, ref)
+	if err != nil {
+		return "", err
 	}
-	num = len(builds) - num
-	return builds[num].ID, nil
+
+	return b.ID, nil
 }
 
 // BuildStatusByID ...
@@ -66,11 +74,14 @@ func (m *Manager) BuildStatusByID(token, project, env, buildID string) (build mo
 		if resp == nil {
 			return build, ErrConnectionRefused
 		}
+		if resp.StatusCode == 401 {
+			return build, helper.ErrNotLoggedIn
+		}
 		if resp.StatusCode == 403 {
-			return build, errors.New("You don't have permissions to perform this action")
+			return build, helper.NewStatusError(403, "You don't have permissions to perform this action")
 		}
 		if resp.StatusCode == 404 {
-			return build, errors.New("Specified build not found")
+			return build, helper.NewStatusError(404, "Specified build not found")
 		}
 		return build, err
 	}
@@ -89,10 +100,10 @@ func (m *Manager) BuildDefinitionByID(token, project, env, buildID string) ([]by
 			return nil, ErrConnectionRefused
 		}
 		if resp.StatusCode == 403 {
-			return nil, errors.New("You don't have permissions to perform this action")
+			return nil, helper.NewStatusError(403, "You don't have permissions to perform this action")
 		}
 		if resp.StatusCode == 404 {
-			return nil, errors.New("Specified build not found")
+			return nil, helper.NewStatusError(404, "Specified build not found")
 		}
 		return nil, err
 	}
@@ -113,9 +124,9 @@ func (m *Manager) LatestBuildDefinition(token, project, env string) ([]byte, err
 	return m.BuildDefinitionByID(token, project, env, id)
 }
 
-// BuildDefinitionFromIndex ...
-func (m *Manager) BuildDefinitionFromIndex(token, project, env, index string) ([]byte, error) {
-	id, err := m.BuildIDFromIndex(token, project, env, index)
+// BuildDefinition : gets the definition of a build given a build reference
+func (m *Manager) BuildDefinition(token, project, env, ref string) ([]byte, error) {
+	id, err := m.ResolveBuildID(token, project, env, ref)
 	if err != nil {
 		return nil, err
 	}
@@ -123,6 +134,22 @@ func (m *Manager) BuildDefinitionFromIndex(token, project, env, index string) ([
 	return m.BuildDefinitionByID(token, project, env, id)
 }
 
+// ExportDefinition : gets the definition of the latest build of an
+// environment, cleaned to be managed declaratively
+func (m *Manager) ExportDefinition(token, project, env string) (d model.Definition, err error) {
+	payload, err := m.LatestBuildDefinition(token, project, env)
+	if err != nil {
+		return d, err
+	}
+
+	if err = d.Load(payload); err != nil {
+		return d, errors.New("Could not process definition yaml")
+	}
+	d.Clean()
+
+	return d, nil
+}
+
 // LatestBuildID ...
 func (m *Manager) LatestBuildID(token, project, env string) (string, error) {
 	builds, err := m.ListBuilds(project, env, token)
@@ -131,7 +158,7 @@ func (m *Manager) LatestBuildID(token, project, env string) (string, error) {
 	}
 
 	if len(builds) < 1 {
-		return "", errors.New("Specified build not found")
+		return "", helper.NewError(helper.ExitNotFound, "Specified build not found")
 	}
 
 	return builds[0].ID, nil
@@ -147,8 +174,22 @@ func (m *Manager) LatestBuildStatus(token, project, env string) (build model.Bui
 	return m.BuildStatusByID(token, project, env, id)
 }
 
-// Apply : Applies a yaml to create / update a new env
-func (m *Manager) Apply(token, path string, credentials map[string]interface{}, monit, dry bool) (string, error) {
+// MonitorBuild : follows the progress of a build, falling back to polling
+// its status when no events are received
+func (m *Manager) MonitorBuild(token, project, env, id string) error {
+	opts := m.Monitor
+	opts.Name = project + "/" + env
+	opts.Poll = func() (string, error) {
+		b, err := m.BuildStatusByID(token, project, env, id)
+		return b.Status, err
+	}
+
+	return helper.Monitorize(m.URL, "/events", token, id, opts)
+}
+
+// Apply : Applies a yaml to create / update a new env, attaching the given
+// metadata to the build
+func (m *Manager) Apply(token, path string, credentials map[string]interface{}, metadata map[string]string, monit, dry bool) (string, error) {
 	var d model.Definition
 
 	payload, err := ioutil.ReadFile(path)
@@ -160,6 +201,18 @@ func (m *Manager) Apply(token, path string, credentials map[string]interface{},
 	if err != nil {
 		return "", errors.New("Could not process definition yaml")
 	}
+	d.ResolveFileImports(filepath.Dir(path))
+
+	// the metadata section is reserved to the client
+	d.StripMetadata()
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		d.SetMetadata(k, metadata[k])
+	}
 
 	_, err = m.EnvStatus(token, d.Project, d.Name)
 	if err != nil {
@@ -211,11 +264,18 @@ func (m *Manager) Import(token string, name string, project string, filters []st
 		return "", errors.New(body)
 	}
 
-	return a.ResourceID, helper.Monitorize(m.URL, "/events", token, a.ResourceID)
+	return a.ResourceID, m.MonitorBuild(token, project, name, a.ResourceID)
 }
 
 // ApplyEnv : Applies a yaml to create / update a new env
 func (m *Manager) ApplyEnv(d model.Definition, token string, credentials map[string]interface{}, monit, dry bool) (string, error) {
+	hooks, err := d.Hooks()
+	if err != nil {
+		return "", err
+	}
+	d.StripHooks()
+	hooks = m.Hooks.Merge(hooks)
+
 	payload, err := d.Save()
 
 	if err != nil {
@@ -226,6 +286,13 @@ func (m *Manager) ApplyEnv(d model.Definition, token string, credentials map[str
 		return m.dryApply(token, payload, d)
 	}
 
+	ctx := hookContext{operation: "apply", project: d.Project, env: d.Name}
+	m.currentBuild(token, hooks, &ctx, model.HookPreApply)
+	if err = m.runHook(hooks, model.HookPreApply, ctx); err != nil {
+		return "", err
+	}
+	ctx = hookContext{operation: "apply", project: d.Project, env: d.Name}
+
 	var response struct {
 		ID      string `json:"id,omitempty"`
 		Name    string `json:"name,omitempty"`
@@ -235,53 +302,97 @@ func (m *Manager) ApplyEnv(d model.Definition, token string, credentials map[str
 
 	body, resp, rerr := m.doRequest("/api/projects/"+d.Project+"/envs/"+d.Name+"/builds/", "POST", payload, token, "application/yaml")
 	if resp == nil {
+		m.failed(token, hooks, ctx)
 		return "", ErrConnectionRefused
 	}
 
 	err = json.Unmarshal([]byte(body), &response)
-	if err != nil {
-		return "", errors.New(body)
+	if rerr != nil {
+		m.failed(token, hooks, ctx)
+		if err != nil || response.Message == "" {
+			return "", helper.WithMessage(rerr, body)
+		}
+		return "", helper.WithMessage(rerr, response.Message)
 	}
 
-	if rerr != nil {
-		return "", errors.New(response.Message)
+	if err != nil {
+		m.failed(token, hooks, ctx)
+		return "", errors.New(body)
 	}
 
 	if monit {
-		err = helper.Monitorize(m.URL, "/events", token, response.ID)
+		ctx.buildID = response.ID
+
+		err = m.MonitorBuild(token, d.Project, d.Name, response.ID)
 		if err != nil {
+			m.failed(token, hooks, ctx)
 			return response.ID, err
 		}
 
-		fmt.Println("================\nPlatform Details\n================\n ")
 		var build model.Build
 
 		build, err = m.BuildStatusByID(token, d.Project, d.Name, response.ID)
 		if err != nil {
 			return response.ID, err
 		}
+		ctx.setBuild(&build)
 
-		view.PrintEnvInfo(&build)
+		// machine readable progress is not followed by the platform details
+		if m.Monitor.Progress != helper.PROGRESSJSON {
+			fmt.Println("================\nPlatform Details\n================\n ")
+			view.PrintEnvInfo(&build)
+		}
+
+		return response.ID, m.runHook(hooks, model.HookPostApply, ctx)
 	}
 
 	return response.ID, nil
 }
 
 func (m *Manager) dryApply(token string, payload []byte, d model.Definition) (string, error) {
-	var body string
+	changes, err := m.dryChanges(token, payload, d)
+	if err != nil {
+		return "", err
+	}
+	view.EnvDry(changes)
+	if len(changes) > 0 {
+		return "", helper.ErrChangesPending
+	}
+	return "", nil
+}
+
+// DryApplyEnv : returns the list of changes applying a definition would perform
+func (m *Manager) DryApplyEnv(token string, d model.Definition) ([]string, error) {
+	d.StripHooks()
+
+	payload, err := d.Save()
+	if err != nil {
+		return nil, errors.New("Could not finalize definition yaml")
+	}
+
+	return m.dryChanges(token, payload, d)
+}
+
+func (m *Manager) dryChanges(token string, payload []byte, d model.Definition) ([]string, error) {
+	var changes []string
+
 	body, resp, err := m.doRequest("/api/projects/"+d.Project+"/envs/"+d.Name+"/builds/?dry=true", "POST", payload, token, "application/yaml")
 	if err != nil {
 		if resp == nil {
-			return "", ErrConnectionRefused
+			return nil, ErrConnectionRefused
 		}
 		var internalError struct {
 			Message string `json:"message"`
 		}
-		if err := json.Unmarshal([]byte(body), &internalError); err != nil {
-			return "", errors.New(body)
+		if jerr := json.Unmarshal([]byte(body), &internalError); jerr != nil || internalError.Message == "" {
+			return nil, helper.WithMessage(err, body)
 		}
-		return "", errors.New(internalError.Message)
+		return nil, helper.WithMessage(err, internalError.Message)
 	}
-	view.EnvDry(body)
-	return "", nil
+
+	if err := json.Unmarshal([]byte(body), &changes); err != nil {
+		return nil, errors.New("Unexpected response from ernest")
+	}
+
+	return changes, nil
 }
@@ -0,0 +1,141 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/model"
+)
+
+// refTimeLayouts are the accepted layouts for @<date> build references
+var refTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// resolveBuildRef : finds the build a reference points to. Builds are
+// expected to be sorted from the newest to the oldest, as the api lists them
+func resolveBuildRef(builds []model.Build, ref string) (model.Build, error) {
+	ref = strings.TrimSpace(ref)
+
+	if len(builds) < 1 {
+		return model.Build{}, helper.NewError(helper.ExitNotFound, "Specified build not found")
+	}
+
+	switch {
+	case ref == "" || ref == "latest":
+		return builds[0], nil
+	case ref == "previous":
+		return buildFromLatest(builds, 1)
+	case strings.HasPrefix(ref, "latest~"):
+		n, err := strconv.Atoi(strings.TrimPrefix(ref, "latest~"))
+		if err != nil || n < 0 {
+			return model.Build{}, helper.NewError(helper.ExitUsage, "Invalid build reference '"+ref+"', expected latest~N")
+		}
+		return buildFromLatest(builds, n)
+	case strings.HasPrefix(ref, "@"):
+		return buildAtDate(builds, strings.TrimPrefix(ref, "@"))
+	case isNumeric(ref):
+		return buildFromIndex(builds, ref)
+	}
+
+	return buildFromID(builds, ref)
+}
+
+// buildFromIndex : returns the build with the given index, or the one its
+// ID starts with the reference when it isn't a valid index, as short build
+// IDs can be made of digits only
+func buildFromIndex(builds []model.Build, ref string) (model.Build, error) {
+	num, err := strconv.Atoi(ref)
+	if err == nil && num >= 1 && num <= len(builds) {
+		return builds[len(builds)-num], nil
+	}
+
+	b, err := buildFromID(builds, ref)
+	if helper.ExitCode(err) == helper.ExitNotFound {
+		return b, helper.NewError(helper.ExitUsage, "Invalid build index "+ref+", this environment has "+strconv.Itoa(len(builds))+" builds, and no build ID starts with it")
+	}
+	return b, err
+}
+
+func buildFromLatest(builds []model.Build, n int) (model.Build, error) {
+	if n >= len(builds) {
+		return model.Build{}, helper.NewError(helper.ExitNotFound, "This environment has only "+strconv.Itoa(len(builds))+" builds")
+	}
+	return builds[n], nil
+}
+
+func buildAtDate(builds []model.Build, date string) (model.Build, error) {
+	at, err := parseRefTime(date)
+	if err != nil {
+		return model.Build{}, err
+	}
+
+	for _, b := range builds {
+		created, err := b.Created()
+		if err != nil {
+			return model.Build{}, err
+		}
+		if !created.After(at) {
+			return b, nil
+		}
+	}
+
+	return model.Build{}, helper.NewError(helper.ExitNotFound, "There was no build for this environment at "+date)
+}
+
+func buildFromID(builds []model.Build, ref string) (model.Build, error) {
+	var matches []model.Build
+
+	for _, b := range builds {
+		if b.ID == ref {
+			return b, nil
+		}
+		if strings.HasPrefix(strings.ToLower(b.ID), strings.ToLower(ref)) {
+			matches = append(matches, b)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return model.Build{}, helper.NewError(helper.ExitNotFound, "No build matches the reference '"+ref+"'")
+	case 1:
+		return matches[0], nil
+	}
+
+	var ids []string
+	for _, b := range matches {
+		ids = append(ids, b.ShortID())
+	}
+
+	return model.Build{}, helper.NewError(helper.ExitUsage, "Build reference '"+ref+"' is ambiguous, it matches builds "+strings.Join(ids, ", ")+". Please use a longer build ID")
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// parseRefTime : parses a date given on a build reference or filter
+func parseRefTime(date string) (time.Time, error) {
+	for _, layout := range refTimeLayouts {
+		if at, err := time.Parse(layout, date); err == nil {
+			return at, nil
+		}
+	}
+
+	return time.Time{}, helper.NewError(helper.ExitUsage, "Invalid build date '"+date+"', expected a date like 2017-09-21 or 2017-09-21T10:30:00Z")
+}
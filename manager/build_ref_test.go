@@ -0,0 +1,93 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/ernestio/ernest-cli/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestResolveBuildRef(t *testing.T) {
+	builds := []model.Build{
+		{ID: "c3d9a1f0-7b6e-4c1a-9d2e-000000000003", CreatedAt: "2017-09-21T12:00:00Z"},
+		{ID: "a1b2c3d4-7b6e-4c1a-9d2e-000000000002", CreatedAt: "2017-09-20T12:00:00Z"},
+		{ID: "a1b9f7e2-7b6e-4c1a-9d2e-000000000001", CreatedAt: "2017-09-19T12:00:00Z"},
+	}
+
+	Convey("Given a list of builds sorted from the newest", t, func() {
+		Convey("When resolving latest, previous and latest~N", func() {
+			b, err := resolveBuildRef(builds, "latest")
+			So(err, ShouldBeNil)
+			So(b.ID, ShouldEqual, builds[0].ID)
+
+			b, err = resolveBuildRef(builds, "previous")
+			So(err, ShouldBeNil)
+			So(b.ID, ShouldEqual, builds[1].ID)
+
+			b, err = resolveBuildRef(builds, "latest~2")
+			So(err, ShouldBeNil)
+			So(b.ID, ShouldEqual, builds[2].ID)
+
+			_, err = resolveBuildRef(builds, "latest~3")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("When resolving a numeric index", func() {
+			b, err := resolveBuildRef(builds, "1")
+			So(err, ShouldBeNil)
+			So(b.ID, ShouldEqual, builds[2].ID)
+
+			_, err = resolveBuildRef(builds, "4")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("When resolving a digits only build ID prefix", func() {
+			numeric := append([]model.Build{{ID: "20170922-7b6e-4c1a-9d2e-000000000004", CreatedAt: "2017-09-22T12:00:00Z"}}, builds...)
+
+			b, err := resolveBuildRef(numeric, "2017")
+			So(err, ShouldBeNil)
+			So(b.ID, ShouldEqual, numeric[0].ID)
+
+			b, err = resolveBuildRef(numeric, "2")
+			So(err, ShouldBeNil)
+			So(b.ID, ShouldEqual, builds[1].ID)
+		})
+
+		Convey("When resolving a build ID prefix", func() {
+			b, err := resolveBuildRef(builds, "c3d9")
+			So(err, ShouldBeNil)
+			So(b.ID, ShouldEqual, builds[0].ID)
+
+			b, err = resolveBuildRef(builds, builds[1].ID)
+			So(err, ShouldBeNil)
+			So(b.ID, ShouldEqual, builds[1].ID)
+
+			_, err = resolveBuildRef(builds, "a1b")
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "ambiguous")
+
+			_, err = resolveBuildRef(builds, "ffff")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("When resolving a date", func() {
+			b, err := resolveBuildRef(builds, "@2017-09-20T18:00:00Z")
+			So(err, ShouldBeNil)
+			So(b.ID, ShouldEqual, builds[1].ID)
+
+			b, err = resolveBuildRef(builds, "@2017-09-22")
+			So(err, ShouldBeNil)
+			So(b.ID, ShouldEqual, builds[0].ID)
+
+			_, err = resolveBuildRef(builds, "@2017-09-01")
+			So(err, ShouldNotBeNil)
+
+			_, err = resolveBuildRef(builds, "@yesterday")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
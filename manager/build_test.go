@@ -0,0 +1,50 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestApplyEnvErrors(t *testing.T) {
+	Convey("Given an api rejecting builds", t, func() {
+		status := http.StatusForbidden
+		body := `{"message": "You don't have permissions to perform this action"}`
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+		defer server.Close()
+
+		m := Manager{URL: server.URL}
+		d := model.NewDefinition("env", "project")
+
+		Convey("When applying is forbidden", func() {
+			_, err := m.ApplyEnv(d, "token", nil, false, false)
+
+			Convey("It should exit with the permission code and the api message", func() {
+				So(helper.ExitCode(err), ShouldEqual, helper.ExitPermission)
+				So(err.Error(), ShouldEqual, "You don't have permissions to perform this action")
+			})
+		})
+
+		Convey("When the environment is busy on a dry run", func() {
+			status = http.StatusConflict
+			body = "environment is locked"
+			_, err := m.ApplyEnv(d, "token", nil, false, true)
+
+			Convey("It should exit with the conflict code and the api response", func() {
+				So(helper.ExitCode(err), ShouldEqual, helper.ExitConflict)
+				So(err.Error(), ShouldEqual, "environment is locked")
+			})
+		})
+	})
+}
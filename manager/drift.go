@@ -0,0 +1,91 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/fatih/color"
+)
+
+// Drift : discovers the live state of an environment importing it into a
+// shadow environment, and compares it with its latest applied build. The
+// shadow environment is removed afterwards. Without filters, only the
+// resources filtered by the environment name are discovered
+func (m *Manager) Drift(token, project, env string, filters []string) (drift model.Drift, err error) {
+	if len(filters) == 0 {
+		filters = []string{env}
+	}
+
+	applied, err := m.appliedBuild(token, project, env)
+	if err != nil {
+		return drift, err
+	}
+
+	shadow := env + "-drift-" + strconv.FormatInt(time.Now().Unix(), 10)
+
+	// the shadow is removed even when interrupted
+	interrupted := make(chan os.Signal, 1)
+	done := make(chan bool)
+	signal.Notify(interrupted, os.Interrupt)
+	defer func() {
+		signal.Stop(interrupted)
+		close(done)
+		m.removeShadow(token, project, shadow)
+	}()
+	go func() {
+		select {
+		case <-interrupted:
+			m.removeShadow(token, project, shadow)
+			os.Exit(helper.ExitError)
+		case <-done:
+		}
+	}()
+
+	id, err := m.Import(token, shadow, project, filters)
+	if err != nil {
+		return drift, err
+	}
+
+	discovered, err := m.BuildStatusByID(token, project, shadow, id)
+	if err != nil {
+		return drift, err
+	}
+
+	return applied.DriftFrom(&discovered), nil
+}
+
+// appliedBuild : returns the newest successful build of an environment
+func (m *Manager) appliedBuild(token, project, env string) (build model.Build, err error) {
+	builds, err := m.ListBuilds(project, env, token)
+	if err != nil {
+		return build, err
+	}
+
+	for _, b := range builds {
+		if b.Status == "done" {
+			return m.BuildStatusByID(token, project, env, b.ID)
+		}
+	}
+
+	return build, helper.NewError(helper.ExitNotFound, "The environment "+project+"/"+env+" has no successful builds to compare")
+}
+
+// removeShadow : removes a shadow environment, only forcing it, as
+// destroying it would also destroy the resources it discovered
+func (m *Manager) removeShadow(token, project, shadow string) {
+	if _, err := m.EnvStatus(token, project, shadow); err != nil {
+		return
+	}
+
+	if err := m.ForceDestroy(token, project, shadow); err != nil {
+		color.Red("Could not remove the shadow environment " + project + "/" + shadow + ", please remove it with 'ernest env delete --force " + project + " " + shadow + "': " + err.Error())
+	}
+}
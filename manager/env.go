@@ -7,11 +7,9 @@ package manager
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 
 	"github.com/ernestio/ernest-cli/helper"
 	"github.com/ernestio/ernest-cli/model"
-	"github.com/ernestio/ernest-cli/view"
 )
 
 // ListEnvs ...
@@ -38,10 +36,10 @@ func (m *Manager) EnvStatus(token, project, env string) (environment model.Env,
 			return environment, ErrConnectionRefused
 		}
 		if resp.StatusCode == 403 {
-			return environment, errors.New("You don't have permissions to perform this action")
+			return environment, helper.NewStatusError(403, "You don't have permissions to perform this action")
 		}
 		if resp.StatusCode == 404 {
-			return environment, errors.New("Specified environment name does not exist")
+			return environment, helper.NewStatusError(404, "Specified environment name does not exist")
 		}
 		return environment, err
 	}
@@ -60,7 +58,7 @@ func (m *Manager) ResetEnv(project, env, token string) error {
 		return err
 	}
 	if e.Status != "in_progress" {
-		return errors.New("The environment '" + project + " / " + env + "' cannot be reset as its status is '" + e.Status + "'")
+		return helper.NewError(helper.ExitConflict, "The environment '"+project+" / "+env+"' cannot be reset as its status is '"+e.Status+"'")
 	}
 	req := []byte(`{"type": "reset"}`)
 	_, resp, err := m.doRequest("/api/projects/"+project+"/envs/"+env+"/actions/", "POST", req, token, "application/json")
@@ -72,68 +70,32 @@ func (m *Manager) ResetEnv(project, env, token string) error {
 	return err
 }
 
-// RevertEnv reverts a env to a previous known state using a build ID
-func (m *Manager) RevertEnv(project, env, buildID, token string, dry bool) (string, error) {
-	// get requested manifest
-	b, err := m.BuildStatus(token, project, env, buildID)
+// RevertDefinition : builds the definition that reverts an environment to
+// the state of a previous build, tagged as a revert of that build
+func (m *Manager) RevertDefinition(token, project, env, ref string) (d model.Definition, b model.Build, err error) {
+	id, err := m.ResolveBuildID(token, project, env, ref)
 	if err != nil {
-		return "", err
+		return d, b, err
 	}
-	payload := []byte(b.Definition)
 
-	// apply requested manifest
-	var d model.Definition
-
-	err = d.Load(payload)
-	if err != nil {
-		return "", errors.New("Could not process definition yaml")
-	}
-
-	payload, err = d.Save()
+	b, err = m.BuildStatusByID(token, project, env, id)
 	if err != nil {
-		return "", errors.New("Could not finalize definition yaml")
-	}
-
-	if dry {
-		return m.dryApply(token, payload, d)
-	}
-
-	var response struct {
-		ID      string `json:"id,omitempty"`
-		Name    string `json:"name,omitempty"`
-		Message string `json:"message,omitempty"`
-	}
-
-	body, resp, rerr := m.doRequest("/api/projects/"+d.Project+"/envs/", "POST", payload, token, "application/yaml")
-	if resp == nil {
-		return "", ErrConnectionRefused
+		return d, b, err
 	}
 
-	err = json.Unmarshal([]byte(body), &response)
+	payload, err := m.BuildDefinitionByID(token, project, env, id)
 	if err != nil {
-		return "", errors.New(body)
+		return d, b, err
 	}
 
-	if rerr != nil {
-		return "", errors.New(response.Message)
-	}
-
-	err = helper.Monitorize(m.URL, "/events", token, response.ID)
-	if err != nil {
-		return "", err
+	if err = d.Load(payload); err != nil {
+		return d, b, errors.New("Could not process definition yaml")
 	}
 
-	fmt.Println("================\nPlatform Details\n================\n ")
-	var build model.Build
+	d.StripMetadata()
+	d.SetMetadata(model.MetadataRevertOf, b.ID)
 
-	build, err = m.BuildStatus(token, project, env, response.ID)
-	if err != nil {
-		return response.ID, err
-	}
-
-	view.PrintEnvInfo(&build)
-
-	return response.ID, nil
+	return d, b, nil
 }
 
 // Destroy : Destroys an existing env
@@ -143,16 +105,25 @@ func (m *Manager) Destroy(token, project, env string, monit bool) error {
 		return err
 	}
 	if s.Status == "in_progress" {
-		return errors.New("The environment " + env + " cannot be destroyed as it is currently '" + s.Status + "'")
+		return helper.NewError(helper.ExitConflict, "The environment "+env+" cannot be destroyed as it is currently '"+s.Status+"'")
+	}
+
+	// destroy hooks get the outputs of the environment being destroyed
+	ctx := hookContext{operation: "destroy", project: project, env: env}
+	m.currentBuild(token, m.Hooks, &ctx, model.HookPreDestroy, model.HookPostDestroy, model.HookOnFailure)
+	if err = m.runHook(m.Hooks, model.HookPreDestroy, ctx); err != nil {
+		return err
 	}
+	ctx.buildID, ctx.status = "", ""
 
 	body, resp, err := m.doRequest("/api/projects/"+project+"/envs/"+env, "DELETE", nil, token, "application/yaml")
 	if err != nil {
+		m.failed(token, m.Hooks, ctx)
 		if resp == nil {
 			return ErrConnectionRefused
 		}
 		if resp.StatusCode == 404 {
-			return errors.New("Specified environment name does not exist")
+			return helper.NewStatusError(404, "Specified environment name does not exist")
 		}
 		return err
 	}
@@ -160,19 +131,25 @@ func (m *Manager) Destroy(token, project, env string, monit bool) error {
 	var res map[string]interface{}
 	err = json.Unmarshal([]byte(body), &res)
 	if err != nil {
+		m.failed(token, m.Hooks, ctx)
 		return err
 	}
 
-	if id, ok := res["id"].(string); ok {
-		err = helper.Monitorize(m.URL, "/events", token, id)
-		if err != nil {
-			return err
-		}
-	} else {
+	id, ok := res["id"].(string)
+	if !ok {
+		m.failed(token, m.Hooks, ctx)
 		return errors.New("could not read response")
 	}
 
-	return nil
+	ctx.buildID = id
+	err = m.MonitorBuild(token, project, env, id)
+	if err != nil {
+		m.failed(token, m.Hooks, ctx)
+		return err
+	}
+
+	ctx.status = "done"
+	return m.runHook(m.Hooks, model.HookPostDestroy, ctx)
 }
 
 // ForceDestroy : Destroys an existing env by forcing it
@@ -183,7 +160,7 @@ func (m *Manager) ForceDestroy(token, project, env string) error {
 			return ErrConnectionRefused
 		}
 		if resp.StatusCode == 404 {
-			return errors.New("Specified environment name does not exist")
+			return helper.NewStatusError(404, "Specified environment name does not exist")
 		}
 		return err
 	}
@@ -210,11 +187,11 @@ func (m *Manager) UpdateEnv(token, name, project string, credentials map[string]
 
 	switch resp.StatusCode {
 	case 404:
-		return errors.New("Specified environment does not exist")
+		return helper.NewStatusError(404, "Specified environment does not exist")
 	case 403:
-		return errors.New("You don't have permissions to perform this action, please login as a resource owner")
+		return helper.NewStatusError(403, "You don't have permissions to perform this action, please login as a resource owner")
 	case 401:
-		return errors.New("Invalid session, please log in")
+		return helper.NewStatusError(401, "Invalid session, please log in")
 	}
 
 	return rerr
@@ -239,9 +216,9 @@ func (m *Manager) CreateEnv(token, name, project string, credentials map[string]
 
 	switch resp.StatusCode {
 	case 404:
-		return errors.New("Specified project does not exist")
+		return helper.NewStatusError(404, "Specified project does not exist")
 	case 403:
-		return errors.New("You don't have permissions to perform this action, please login as a resource owner")
+		return helper.NewStatusError(403, "You don't have permissions to perform this action, please login as a resource owner")
 	}
 
 	return rerr
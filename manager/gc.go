@@ -0,0 +1,128 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"path"
+	"sync"
+	"time"
+
+	"github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/model"
+)
+
+// GCOptions : selects the environments to garbage collect
+type GCOptions struct {
+	// Pattern is a project/env glob the environments should match
+	Pattern string
+	// OlderThan is the minimum time since the latest build was created
+	OlderThan time.Duration
+	// Idle is the minimum time since the latest build finished, zero
+	// doesn't check it
+	Idle time.Duration
+}
+
+// StaleEnvs : returns the environments matching the garbage collection
+// options. Environments without builds or being built are never returned
+func (m *Manager) StaleEnvs(token string, opts GCOptions, now time.Time) ([]model.StaleEnv, error) {
+	if _, err := path.Match(opts.Pattern, ""); err != nil {
+		return nil, helper.NewError(helper.ExitUsage, "Invalid environment pattern '"+opts.Pattern+"'")
+	}
+
+	envs, err := m.ListEnvs(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []model.StaleEnv
+	for _, e := range envs {
+		if ok, _ := path.Match(opts.Pattern, e.Project+"/"+e.Name); !ok {
+			continue
+		}
+
+		builds, err := m.ListBuilds(e.Project, e.Name, token)
+		if err != nil {
+			return nil, err
+		}
+		if len(builds) == 0 || builds[0].Status == "in_progress" {
+			continue
+		}
+
+		s, err := staleEnv(e, builds, now)
+		if err != nil {
+			return nil, err
+		}
+
+		if s.Age < opts.OlderThan || s.Idle < opts.Idle {
+			continue
+		}
+
+		stale = append(stale, s)
+	}
+
+	return stale, nil
+}
+
+// staleEnv : describes an environment from its builds, listed newest first
+func staleEnv(e model.Env, builds []model.Build, now time.Time) (model.StaleEnv, error) {
+	latest := builds[0]
+
+	created, err := latest.Created()
+	if err != nil {
+		return model.StaleEnv{}, err
+	}
+
+	finished, err := latest.Updated()
+	if err != nil || finished.Before(created) {
+		finished = created
+	}
+
+	return model.StaleEnv{
+		Project: e.Project,
+		Name:    e.Name,
+		Creator: builds[len(builds)-1].UserName,
+		Age:     now.Sub(created),
+		Idle:    now.Sub(finished),
+	}, nil
+}
+
+// CollectEnvs : destroys the given environments, at most concurrency of them
+// at the same time, setting the result of each one
+func (m *Manager) CollectEnvs(token string, envs []model.StaleEnv, concurrency int) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+
+	for i := range envs {
+		wg.Add(1)
+		slots <- struct{}{}
+
+		go func(e *model.StaleEnv) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			// the progress of every environment is followed at the same time
+			c := *m
+			c.Monitor.Prefix = true
+			if c.Monitor.Progress != helper.PROGRESSJSON {
+				c.Monitor.Progress = helper.PROGRESSPLAIN
+			}
+
+			e.Err = c.Destroy(token, e.Project, e.Name, true)
+			if e.Err != nil {
+				e.Result = "failed"
+				return
+			}
+			e.Result = "destroyed"
+		}(&envs[i])
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,39 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ernestio/ernest-cli/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStaleEnv(t *testing.T) {
+	builds := []model.Build{
+		{ID: "2", UserName: "bob", CreatedAt: "2017-09-20T12:00:00Z", UpdatedAt: "2017-09-20T18:00:00Z"},
+		{ID: "1", UserName: "ann", CreatedAt: "2017-09-10T12:00:00Z", UpdatedAt: "2017-09-10T12:30:00Z"},
+	}
+	now := time.Date(2017, 9, 27, 12, 0, 0, 0, time.UTC)
+
+	Convey("Given the builds of an environment sorted from the newest", t, func() {
+		s, err := staleEnv(model.Env{Project: "p", Name: "feature-a"}, builds, now)
+		So(err, ShouldBeNil)
+
+		Convey("It should measure its age from the latest build creation", func() {
+			So(s.Age, ShouldEqual, 7*24*time.Hour)
+		})
+
+		Convey("It should measure its idle time from the latest build end", func() {
+			So(s.Idle, ShouldEqual, 7*24*time.Hour-6*time.Hour)
+		})
+
+		Convey("It should be created by the user of its first build", func() {
+			So(s.Creator, ShouldEqual, "ann")
+			So(s.FullName(), ShouldEqual, "p/feature-a")
+		})
+	})
+}
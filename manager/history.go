@@ -0,0 +1,160 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"time"
+
+	"github.com/ernestio/ernest-cli/model"
+)
+
+// HistoryOptions : selects the builds shown on an environment history
+type HistoryOptions struct {
+	Status string
+	User   string
+	Since  string
+	Until  string
+	// Limit is the number of builds per page, zero shows them all
+	Limit int
+	// Page is the page of builds to show, starting on 1
+	Page int
+	// WithChanges loads the changes of every build from the previous one
+	WithChanges bool
+}
+
+// BuildHistory : returns the builds of an environment matching the options,
+// newest first, and the number of builds matching before paginating them
+func (m *Manager) BuildHistory(token, project, env string, opts HistoryOptions) ([]model.HistoryEntry, int, error) {
+	builds, err := m.ListBuilds(project, env, token)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	match, err := historyFilter(opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var entries []model.HistoryEntry
+	for _, e := range model.BuildHistory(builds) {
+		if match(e.Build) {
+			entries = append(entries, e)
+		}
+	}
+	total := len(entries)
+
+	entries = paginate(entries, opts.Limit, opts.Page)
+
+	if opts.WithChanges {
+		if err := m.loadChanges(token, project, env, builds, entries); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return entries, total, nil
+}
+
+// historyFilter : returns a function matching the builds selected by the options
+func historyFilter(opts HistoryOptions) (func(model.Build) bool, error) {
+	var since, until time.Time
+	var err error
+
+	if opts.Since != "" {
+		if since, err = parseRefTime(opts.Since); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Until != "" {
+		if until, err = parseRefTime(opts.Until); err != nil {
+			return nil, err
+		}
+		// a day includes all of its builds
+		if len(opts.Until) == len("2006-01-02") {
+			until = until.Add(24 * time.Hour)
+		}
+	}
+
+	return func(b model.Build) bool {
+		if opts.Status != "" && b.Status != opts.Status {
+			return false
+		}
+		if opts.User != "" && b.UserName != opts.User {
+			return false
+		}
+		if since.IsZero() && until.IsZero() {
+			return true
+		}
+
+		created, err := b.Created()
+		if err != nil {
+			return false
+		}
+		if !since.IsZero() && created.Before(since) {
+			return false
+		}
+		if !until.IsZero() && !created.Before(until) {
+			return false
+		}
+		return true
+	}, nil
+}
+
+func paginate(entries []model.HistoryEntry, limit, page int) []model.HistoryEntry {
+	if limit <= 0 {
+		return entries
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	start := (page - 1) * limit
+	if start >= len(entries) {
+		return nil
+	}
+
+	end := start + limit
+	if end > len(entries) {
+		end = len(entries)
+	}
+
+	return entries[start:end]
+}
+
+// loadChanges : compares every entry build with the build before it
+func (m *Manager) loadChanges(token, project, env string, builds []model.Build, entries []model.HistoryEntry) error {
+	loaded := make(map[string]*model.Build)
+
+	load := func(id string) (*model.Build, error) {
+		if b, ok := loaded[id]; ok {
+			return b, nil
+		}
+		b, err := m.BuildStatusByID(token, project, env, id)
+		if err != nil {
+			return nil, err
+		}
+		loaded[id] = &b
+		return &b, nil
+	}
+
+	for i := range entries {
+		current, err := load(entries[i].Build.ID)
+		if err != nil {
+			return err
+		}
+
+		// builds are listed newest first, the previous build is the next one
+		previous := &model.Build{}
+		if pos := len(builds) - entries[i].Index + 1; pos < len(builds) {
+			if previous, err = load(builds[pos].ID); err != nil {
+				return err
+			}
+		}
+
+		changes := current.ChangesSince(previous)
+		entries[i].Changes = &changes
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/model"
+)
+
+// buildIDTrailer is the commit trailer identifying the build a commit exports
+const buildIDTrailer = "Build-ID"
+
+// ExportHistory : commits the definition of every finished build of an
+// environment, from the oldest to the newest, to a git repository. Builds
+// exported on previous runs are skipped, and the export stops at the first
+// unfinished build, returned so it's exported in order on a later run
+func (m *Manager) ExportHistory(token, project, env, dir string) ([]model.Build, *model.Build, error) {
+	builds, err := m.ListBuilds(project, env, token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := helper.GitInit(dir); err != nil {
+		return nil, nil, err
+	}
+
+	ids, err := helper.GitTrailers(dir, buildIDTrailer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	exported := make(map[string]bool)
+	for _, id := range ids {
+		exported[id] = true
+	}
+
+	file := env + ".yml"
+
+	var commits []model.Build
+	for i := len(builds) - 1; i >= 0; i-- {
+		b := builds[i]
+		if exported[b.ID] {
+			continue
+		}
+		if b.Status == "in_progress" {
+			return commits, &b, nil
+		}
+
+		definition, err := m.BuildDefinitionByID(token, project, env, b.ID)
+		if err != nil {
+			return commits, nil, err
+		}
+
+		if err := ioutil.WriteFile(filepath.Join(dir, file), definition, 0644); err != nil {
+			return commits, nil, err
+		}
+
+		if err := helper.GitCommitFiles(dir, []string{file}, buildCommit(b)); err != nil {
+			return commits, nil, err
+		}
+
+		commits = append(commits, b)
+	}
+
+	return commits, nil, nil
+}
+
+// buildCommit : describes a build as a commit
+func buildCommit(b model.Build) helper.GitCommit {
+	date := b.CreatedAt
+	if t, err := b.Created(); err == nil {
+		date = t.Format(time.RFC3339)
+	}
+
+	author := b.UserName
+	if author == "" {
+		author = "ernest"
+	}
+
+	return helper.GitCommit{
+		Author: author,
+		Date:   date,
+		Message: "Build " + b.ShortID() + " " + b.Status + "\n\n" +
+			buildIDTrailer + ": " + b.ID + "\n" +
+			"Build-Status: " + b.Status + "\n",
+	}
+}
@@ -0,0 +1,115 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+
+	"github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/fatih/color"
+)
+
+// hookContext : the operation a hook runs around, passed to its commands
+// as environment variables
+type hookContext struct {
+	operation string
+	project   string
+	env       string
+	buildID   string
+	status    string
+	outputs   map[string]string
+}
+
+// setBuild : sets the build the hook runs for
+func (h *hookContext) setBuild(b *model.Build) {
+	h.buildID = b.ID
+	h.status = b.Status
+	h.outputs = b.Outputs()
+}
+
+// currentBuild : sets the latest build of the environment, if any, when
+// some of the given hooks are declared
+func (m *Manager) currentBuild(token string, hooks model.Hooks, ctx *hookContext, names ...string) {
+	for _, name := range names {
+		if len(hooks[name]) == 0 {
+			continue
+		}
+		if b, err := m.LatestBuildStatus(token, ctx.project, ctx.env); err == nil {
+			ctx.setBuild(&b)
+		}
+		return
+	}
+}
+
+// runHook : runs the commands of a lifecycle hook
+func (m *Manager) runHook(hooks model.Hooks, name string, ctx hookContext) error {
+	commands := hooks[name]
+	if len(commands) == 0 {
+		return nil
+	}
+
+	outputs := ctx.outputs
+	if outputs == nil {
+		outputs = map[string]string{}
+	}
+
+	data, err := json.MarshalIndent(outputs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile("", "ernest-outputs")
+	if err != nil {
+		return errors.New("Can't write the outputs file of the " + name + " hook")
+	}
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return errors.New("Can't write the outputs file of the " + name + " hook")
+	}
+
+	env := []string{
+		"ERNEST_HOOK=" + name,
+		"ERNEST_OPERATION=" + ctx.operation,
+		"ERNEST_PROJECT=" + ctx.project,
+		"ERNEST_ENV=" + ctx.env,
+		"ERNEST_BUILD_ID=" + ctx.buildID,
+		"ERNEST_BUILD_STATUS=" + ctx.status,
+		"ERNEST_OUTPUTS=" + f.Name(),
+	}
+
+	return helper.RunHook(name, commands, env)
+}
+
+// failed : runs the on-failure hook of an operation, a failure of the hook
+// itself is only reported, as the operation error is the one returned
+func (m *Manager) failed(token string, hooks model.Hooks, ctx hookContext) {
+	if len(hooks[model.HookOnFailure]) == 0 {
+		return
+	}
+
+	if ctx.buildID != "" {
+		if b, err := m.BuildStatusByID(token, ctx.project, ctx.env, ctx.buildID); err == nil {
+			ctx.setBuild(&b)
+		}
+	}
+	if ctx.status == "" || ctx.status == "done" {
+		ctx.status = "errored"
+	}
+
+	if err := m.runHook(hooks, model.HookOnFailure, ctx); err != nil {
+		color.Red(err.Error())
+	}
+}
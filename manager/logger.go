@@ -6,8 +6,8 @@ package manager
 
 import (
 	"encoding/json"
-	"errors"
 
+	"github.com/ernestio/ernest-cli/helper"
 	"github.com/ernestio/ernest-cli/model"
 )
 
@@ -38,10 +38,10 @@ func (m *Manager) SetLogger(token string, logger model.Logger) (err error) {
 			return ErrConnectionRefused
 		}
 		if resp.StatusCode == 403 {
-			return errors.New("You're not allowed to perform this action, please log in with an admin account")
+			return helper.NewStatusError(403, "You're not allowed to perform this action, please log in with an admin account")
 		}
 
-		return errors.New(string(body))
+		return helper.WithMessage(err, string(body))
 	}
 
 	return nil
@@ -58,9 +58,9 @@ func (m *Manager) DelLogger(token string, logger model.Logger) (err error) {
 			return ErrConnectionRefused
 		}
 		if resp.StatusCode == 403 {
-			return errors.New("You're not allowed to perform this action, please log in with an admin account")
+			return helper.NewStatusError(403, "You're not allowed to perform this action, please log in with an admin account")
 		}
-		return errors.New(string(body))
+		return helper.WithMessage(err, string(body))
 	}
 
 	return nil
@@ -7,7 +7,6 @@ package manager
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"io/ioutil"
 	"net/http"
 
@@ -40,7 +39,7 @@ func (m *Manager) Login(username string, password string) (token string, err err
 
 	if resp.StatusCode != 200 {
 		e := helper.ResponseMessage(body)
-		return "", errors.New(e.Message)
+		return "", helper.NewError(helper.ExitAuth, e.Message)
 	}
 
 	err = json.Unmarshal(body, &t)
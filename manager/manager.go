@@ -14,13 +14,17 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/model"
 	"github.com/fatih/color"
 )
 
 // Manager manages all api communications
 type Manager struct {
-	URL     string `json:"url"`
-	Version string `json:"version"`
+	URL     string                `json:"url"`
+	Version string                `json:"version"`
+	Monitor helper.MonitorOptions `json:"-"`
+	Hooks   model.Hooks           `json:"-"`
 }
 
 // Token holds the JWT token that is received when authenticating
@@ -87,7 +91,7 @@ func (m *Manager) doRequestWithQuery(url, method string, payload []byte, token s
 	body := string(responseBody)
 
 	if resp.StatusCode != 200 {
-		return body, resp, errors.New(resp.Status)
+		return body, resp, helper.NewStatusError(resp.StatusCode, resp.Status)
 	}
 	return body, resp, nil
 }
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"strconv"
 
+	"github.com/ernestio/ernest-cli/helper"
 	"github.com/ernestio/ernest-cli/model"
 )
 
@@ -33,7 +34,7 @@ func (m *Manager) CreateNotification(token string, name string, ntype string, co
 			return "Notification '" + name + "' already exists, please specify a different name", err
 		}
 		if res.StatusCode == 403 {
-			return body, errors.New(body)
+			return body, helper.NewStatusError(403, body)
 		}
 		return body, err
 	}
@@ -48,7 +49,7 @@ func (m *Manager) ListNotifications(token string) (notifications []model.Notific
 			return nil, ErrConnectionRefused
 		}
 		if res.StatusCode == 403 {
-			return nil, errors.New(body)
+			return nil, helper.NewStatusError(403, body)
 		}
 		return nil, err
 	}
@@ -73,10 +74,10 @@ func (m *Manager) DeleteNotification(token string, name string) (err error) {
 			return ErrConnectionRefused
 		}
 		if res.StatusCode == 400 {
-			return errors.New(body)
+			return helper.WithMessage(err, body)
 		}
 		if res.StatusCode == 403 {
-			return errors.New(body)
+			return helper.NewStatusError(403, body)
 		}
 
 		return err
@@ -106,10 +107,10 @@ func (m *Manager) UpdateNotification(token, name, config string) (err error) {
 			return ErrConnectionRefused
 		}
 		if res.StatusCode == 400 {
-			return errors.New(body)
+			return helper.WithMessage(err, body)
 		}
 		if res.StatusCode == 403 {
-			return errors.New(body)
+			return helper.NewStatusError(403, body)
 		}
 
 		return err
@@ -144,10 +145,10 @@ func (m *Manager) AddServiceToNotification(token, service, name string, delete b
 			return ErrConnectionRefused
 		}
 		if res.StatusCode == 400 {
-			return errors.New(body)
+			return helper.WithMessage(err, body)
 		}
 		if res.StatusCode == 403 {
-			return errors.New(body)
+			return helper.NewStatusError(403, body)
 		}
 
 		return err
@@ -6,9 +6,9 @@ package manager
 
 import (
 	"encoding/json"
-	"errors"
 	"strconv"
 
+	"github.com/ernestio/ernest-cli/helper"
 	"github.com/ernestio/ernest-cli/model"
 )
 
@@ -80,7 +80,7 @@ func (m *Manager) ListProjects(token string) (projects []model.Project, err erro
 func (m *Manager) DeleteProject(token string, name string) (err error) {
 	g, err := m.getProjectByName(token, name)
 	if err != nil {
-		return errors.New("Project '" + name + "' does not exist, please specify a different project name")
+		return helper.NewError(helper.ExitNotFound, "Project '"+name+"' does not exist, please specify a different project name")
 	}
 	id := strconv.Itoa(g.ID)
 
@@ -90,7 +90,7 @@ func (m *Manager) DeleteProject(token string, name string) (err error) {
 			return ErrConnectionRefused
 		}
 		if res.StatusCode == 400 {
-			return errors.New(body)
+			return helper.WithMessage(err, body)
 		}
 
 		return err
@@ -102,7 +102,7 @@ func (m *Manager) DeleteProject(token string, name string) (err error) {
 func (m *Manager) UpdateVCloudProject(token, name, user, password string) (err error) {
 	g, err := m.getProjectByName(token, name)
 	if err != nil {
-		return errors.New("Project '" + name + "' does not exist, please specify a different project name")
+		return helper.NewError(helper.ExitNotFound, "Project '"+name+"' does not exist, please specify a different project name")
 	}
 	id := strconv.Itoa(g.ID)
 
@@ -113,7 +113,7 @@ func (m *Manager) UpdateVCloudProject(token, name, user, password string) (err e
 			return ErrConnectionRefused
 		}
 		if res.StatusCode == 400 {
-			return errors.New(body)
+			return helper.WithMessage(err, body)
 		}
 
 		return err
@@ -126,7 +126,7 @@ func (m *Manager) UpdateVCloudProject(token, name, user, password string) (err e
 func (m *Manager) UpdateAWSProject(token, name, awsAccessKeyID, awsSecretAccessKey string) (err error) {
 	g, err := m.getProjectByName(token, name)
 	if err != nil {
-		return errors.New("Project '" + name + "' does not exist, please specify a different project name")
+		return helper.NewError(helper.ExitNotFound, "Project '"+name+"' does not exist, please specify a different project name")
 	}
 	id := strconv.Itoa(g.ID)
 
@@ -137,7 +137,7 @@ func (m *Manager) UpdateAWSProject(token, name, awsAccessKeyID, awsSecretAccessK
 			return ErrConnectionRefused
 		}
 		if res.StatusCode == 400 {
-			return errors.New(body)
+			return helper.WithMessage(err, body)
 		}
 
 		return err
@@ -150,7 +150,7 @@ func (m *Manager) UpdateAWSProject(token, name, awsAccessKeyID, awsSecretAccessK
 func (m *Manager) UpdateAzureProject(token, name, subscriptionID, clientID, clientSecret, tenantID, environment string) (err error) {
 	g, err := m.getProjectByName(token, name)
 	if err != nil {
-		return errors.New("Project '" + name + "' does not exist, please specify a different project name")
+		return helper.NewError(helper.ExitNotFound, "Project '"+name+"' does not exist, please specify a different project name")
 	}
 	id := strconv.Itoa(g.ID)
 
@@ -161,7 +161,7 @@ func (m *Manager) UpdateAzureProject(token, name, subscriptionID, clientID, clie
 			return ErrConnectionRefused
 		}
 		if res.StatusCode == 400 {
-			return errors.New(body)
+			return helper.WithMessage(err, body)
 		}
 
 		return err
@@ -5,7 +5,7 @@
 package manager
 
 import (
-	"errors"
+	"github.com/ernestio/ernest-cli/helper"
 )
 
 // GetUsageReport : Get the usage report
@@ -13,10 +13,10 @@ func (m *Manager) GetUsageReport(token, from, to string) (body string, err error
 	body, resp, err := m.doRequest("/api/reports/usage/?from="+from+"&to="+to, "GET", []byte(""), token, "")
 	if err != nil {
 		if resp.StatusCode == 403 {
-			return body, errors.New("You're not allowed to perform this action, please log in with an admin account")
+			return body, helper.NewStatusError(403, "You're not allowed to perform this action, please log in with an admin account")
 		}
 
-		return body, errors.New(string(body))
+		return body, helper.WithMessage(err, string(body))
 	}
 
 	return body, nil
@@ -22,10 +22,10 @@ func (m *Manager) ListUsers(token string) (users []model.User, err error) {
 			return nil, ErrConnectionRefused
 		}
 		if resp.StatusCode == 400 {
-			return users, errors.New("You're not allowed to perform this action, please log in")
+			return users, helper.NewError(helper.ExitAuth, "You're not allowed to perform this action, please log in")
 		}
 		if resp.StatusCode == 404 {
-			return users, errors.New("Couldn't found any users")
+			return users, helper.NewStatusError(404, "Couldn't found any users")
 		}
 		return nil, err
 	}
@@ -72,9 +72,9 @@ func (m *Manager) CreateUser(token string, name string, email string, user strin
 		if resp.StatusCode != 200 {
 			e := helper.ResponseMessage([]byte(body))
 			if strings.Contains(e.Message, "invalid jwt") {
-				return errors.New("You're not allowed to perform this action, please log in")
+				return helper.NewError(helper.ExitAuth, "You're not allowed to perform this action, please log in")
 			}
-			return errors.New(e.Message)
+			return helper.WithMessage(err, e.Message)
 		}
 		return err
 	}
@@ -88,7 +88,7 @@ func (m *Manager) ChangePassword(token string, userid int, username string, user
 	if err != nil {
 		if resp.StatusCode != 200 {
 			e := helper.ResponseMessage([]byte(body))
-			return errors.New(e.Message)
+			return helper.WithMessage(err, e.Message)
 		}
 		return err
 	}
@@ -102,7 +102,7 @@ func (m *Manager) ChangePasswordByAdmin(token string, userid int, username strin
 	if err != nil {
 		if resp.StatusCode != 200 {
 			e := helper.ResponseMessage([]byte(body))
-			return errors.New(e.Message)
+			return helper.WithMessage(err, e.Message)
 		}
 		return err
 	}
@@ -4,6 +4,13 @@
 
 package model
 
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+)
+
 // Build : Model representing  env json responses
 type Build struct {
 	ID          string   `json:"id"`
@@ -74,4 +81,142 @@ type Build struct {
 		Name       string `json:"name"`
 		ServerName string `json:"server_name"`
 	} `json:"sql_databases"`
+	// Raw holds the full build payload, including the component
+	// collections not mapped above
+	Raw map[string]json.RawMessage `json:"-"`
+}
+
+// KnownCollections are the component collections mapped on the build fields
+var KnownCollections = []string{
+	"vpcs",
+	"networks",
+	"instances",
+	"nats",
+	"security_groups",
+	"elbs",
+	"rds_clusters",
+	"rds_instances",
+	"ebs_volumes",
+	"load_balancers",
+	"virtual_machines",
+	"sql_databases",
+}
+
+// UnmarshalJSON : loads a build keeping its full payload
+func (b *Build) UnmarshalJSON(data []byte) error {
+	type build Build
+
+	var v build
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*b = Build(v)
+
+	return json.Unmarshal(data, &b.Raw)
+}
+
+// Collections : returns the names of the component collections on the build
+// payload, sorted
+func (b *Build) Collections() []string {
+	var names []string
+
+	for name := range b.Raw {
+		if _, err := b.Collection(name); err == nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Collection : returns the components of a collection on the build payload
+func (b *Build) Collection(name string) ([]map[string]interface{}, error) {
+	var components []map[string]interface{}
+
+	raw, ok := b.Raw[name]
+	if !ok {
+		return nil, errors.New("The build has no '" + name + "' components")
+	}
+
+	if err := json.Unmarshal(raw, &components); err != nil || len(components) == 0 {
+		return nil, errors.New("'" + name + "' is not a component collection")
+	}
+
+	return components, nil
+}
+
+// buildTimeLayouts are the layouts the api may use to format build dates
+var buildTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// ParseBuildTime : parses a date as formatted by the api
+func ParseBuildTime(value string) (time.Time, error) {
+	for _, layout := range buildTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("Invalid build date '" + value + "'")
+}
+
+// Created : returns the time the build was created at
+func (b *Build) Created() (time.Time, error) {
+	return ParseBuildTime(b.CreatedAt)
+}
+
+// Updated : returns the time the build was last updated at
+func (b *Build) Updated() (time.Time, error) {
+	return ParseBuildTime(b.UpdatedAt)
+}
+
+// ShortID : returns the abbreviated build ID
+func (b *Build) ShortID() string {
+	if len(b.ID) > 8 {
+		return b.ID[:8]
+	}
+	return b.ID
+}
+
+// Metadata : returns the metadata the client attached to the build definition
+func (b *Build) Metadata() map[string]string {
+	var d Definition
+	if err := d.Load([]byte(b.Definition)); err != nil {
+		return map[string]string{}
+	}
+	return d.Metadata()
+}
+
+// DefinitionWithoutMetadata : returns the build definition without the
+// metadata the client attached to it, so it doesn't show on diffs
+func (b *Build) DefinitionWithoutMetadata() string {
+	var d Definition
+	if err := d.Load([]byte(b.Definition)); err != nil {
+		return b.Definition
+	}
+
+	// both sides of a diff are saved the same way
+	d.StripMetadata()
+	data, err := d.Save()
+	if err != nil {
+		return b.Definition
+	}
+	return string(data)
+}
+
+// CommitDescription : describes the git commit a build was applied from as
+// its abbreviated sha, flagged with a + when the working tree had changes
+func CommitDescription(metadata map[string]string) string {
+	commit := metadata[MetadataGitCommit]
+	if len(commit) > 8 {
+		commit = commit[:8]
+	}
+	if commit != "" && metadata[MetadataGitDirty] == "true" {
+		commit += "+"
+	}
+	return commit
 }
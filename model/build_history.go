@@ -0,0 +1,151 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryEntry : a build of an environment history
+type HistoryEntry struct {
+	// Index is the position of the build on the history, 1 being the oldest
+	Index int
+	Build Build
+	// Current is true for the build currently deployed, the newest done one
+	Current bool
+	// RevertOf is the index or short ID of the build this build reverted to
+	RevertOf string
+	// Changes are the changes of the build from the previous one, if loaded
+	Changes *BuildChanges
+	// Commit describes the git commit the build was applied from, if known
+	Commit string
+	// Message is the message the build was applied with
+	Message string
+}
+
+// Duration : returns how long the build took, zero if it is still running
+func (e *HistoryEntry) Duration() time.Duration {
+	if e.Build.Status == "in_progress" {
+		return 0
+	}
+
+	created, err := e.Build.Created()
+	if err != nil {
+		return 0
+	}
+	updated, err := e.Build.Updated()
+	if err != nil || updated.Before(created) {
+		return 0
+	}
+
+	return updated.Sub(created)
+}
+
+// BuildChanges : the number of components a build added, changed and removed
+type BuildChanges struct {
+	Added   int
+	Changed int
+	Removed int
+}
+
+// String : summarizes the changes as +added ~changed -removed
+func (c BuildChanges) String() string {
+	return "+" + strconv.Itoa(c.Added) + " ~" + strconv.Itoa(c.Changed) + " -" + strconv.Itoa(c.Removed)
+}
+
+// BuildHistory : describes the builds of an environment, listed newest first
+func BuildHistory(builds []Build) []HistoryEntry {
+	indexes := make(map[string]int)
+	for i, b := range builds {
+		indexes[b.ID] = len(builds) - i
+	}
+
+	entries := make([]HistoryEntry, len(builds))
+	current := false
+
+	for i, b := range builds {
+		metadata := b.Metadata()
+		entries[i] = HistoryEntry{
+			Index:    len(builds) - i,
+			Build:    b,
+			RevertOf: revertOf(metadata[MetadataRevertOf], indexes),
+			Commit:   CommitDescription(metadata),
+			Message:  metadata[MetadataMessage],
+		}
+		if !current && b.Status == "done" {
+			entries[i].Current = true
+			current = true
+		}
+	}
+
+	return entries
+}
+
+// revertOf : describes the build a revert build was created from
+func revertOf(id string, indexes map[string]int) string {
+	if id == "" {
+		return ""
+	}
+	if index, ok := indexes[id]; ok {
+		return strconv.Itoa(index)
+	}
+	reverted := Build{ID: id}
+	return reverted.ShortID()
+}
+
+// ChangesSince : compares the components of a build with the ones of the
+// previous build
+func (b *Build) ChangesSince(previous *Build) BuildChanges {
+	var c BuildChanges
+
+	before := previous.components()
+	after := b.components()
+
+	for id, component := range after {
+		old, ok := before[id]
+		switch {
+		case !ok:
+			c.Added++
+		case !reflect.DeepEqual(old, component):
+			c.Changed++
+		}
+	}
+	for id := range before {
+		if _, ok := after[id]; !ok {
+			c.Removed++
+		}
+	}
+
+	return c
+}
+
+// components : returns the components of a build by collection and name,
+// without their internal fields
+func (b *Build) components() map[string]map[string]interface{} {
+	components := make(map[string]map[string]interface{})
+
+	for _, name := range b.Collections() {
+		collection, _ := b.Collection(name)
+		for i, c := range collection {
+			id, _ := c["name"].(string)
+			if id == "" {
+				id = strconv.Itoa(i)
+			}
+
+			fields := make(map[string]interface{})
+			for k, v := range c {
+				if !strings.HasPrefix(k, "_") {
+					fields[k] = v
+				}
+			}
+			components[name+"."+id] = fields
+		}
+	}
+
+	return components
+}
@@ -0,0 +1,46 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import "regexp"
+
+// instanceIndex matches the index ernest appends to the instances of a group
+var instanceIndex = regexp.MustCompile(`-[0-9]+$`)
+
+// Host : a machine of a build that can be reached on the network
+type Host struct {
+	Name      string
+	Group     string
+	PublicIP  string
+	PrivateIP string
+}
+
+// Address : returns the public or private ip of the host
+func (h *Host) Address(private bool) string {
+	if private {
+		return h.PrivateIP
+	}
+	return h.PublicIP
+}
+
+// Hosts : returns the instances and virtual machines of a build, grouped by
+// the name of the instance group they belong to
+func (b *Build) Hosts() []Host {
+	var hosts []Host
+
+	for _, v := range b.Instances {
+		hosts = append(hosts, Host{Name: v.Name, Group: hostGroup(v.Name), PublicIP: v.PublicIP, PrivateIP: v.IP})
+	}
+	for _, v := range b.VirtualMachines {
+		hosts = append(hosts, Host{Name: v.Name, Group: hostGroup(v.Name), PublicIP: v.PublicIP, PrivateIP: v.PrivateIP})
+	}
+
+	return hosts
+}
+
+// hostGroup : returns the instance group name of an instance, web for web-1
+func hostGroup(name string) string {
+	return instanceIndex.ReplaceAllString(name, "")
+}
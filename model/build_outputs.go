@@ -0,0 +1,69 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+// Outputs : returns the values of the components of a build as a flat map,
+// addressed as <collection>.<component name>.<field>
+func (b *Build) Outputs() map[string]string {
+	o := outputs{}
+
+	for _, v := range b.VPCs {
+		o.set("vpcs", v.Name, "id", v.ID)
+		o.set("vpcs", v.Name, "subnet", v.Subnet)
+	}
+	for _, v := range b.Networks {
+		o.set("networks", v.Name, "id", v.Subnet)
+		o.set("networks", v.Name, "availability_zone", v.AvailabilityZone)
+	}
+	for _, v := range b.Instances {
+		o.set("instances", v.Name, "id", v.InstanceAWSID)
+		o.set("instances", v.Name, "public_ip", v.PublicIP)
+		o.set("instances", v.Name, "private_ip", v.IP)
+	}
+	for _, v := range b.Nats {
+		o.set("nats", v.Name, "id", v.NatGatewayAWSID)
+		o.set("nats", v.Name, "public_ip", v.IP)
+	}
+	for _, v := range b.SecurityGroups {
+		o.set("security_groups", v.Name, "id", v.SecurityGroupAWSID)
+	}
+	for _, v := range b.ELBs {
+		o.set("elbs", v.Name, "dns_name", v.DNSName)
+	}
+	for _, v := range b.RDSClusters {
+		o.set("rds_clusters", v.Name, "endpoint", v.Endpoint)
+	}
+	for _, v := range b.RDSInstances {
+		o.set("rds_instances", v.Name, "endpoint", v.Endpoint)
+	}
+	for _, v := range b.EBSVolumes {
+		o.set("ebs_volumes", v.Name, "id", v.VolumeAWSID)
+	}
+	for _, v := range b.LoadBalancers {
+		o.set("load_balancers", v.Name, "id", v.ID)
+		o.set("load_balancers", v.Name, "public_ip", v.PublicIP)
+	}
+	for _, v := range b.VirtualMachines {
+		o.set("virtual_machines", v.Name, "id", v.ID)
+		o.set("virtual_machines", v.Name, "public_ip", v.PublicIP)
+		o.set("virtual_machines", v.Name, "private_ip", v.PrivateIP)
+	}
+	for _, v := range b.SQLDatabases {
+		o.set("sql_databases", v.Name, "id", v.ID)
+		o.set("sql_databases", v.Name, "server_name", v.ServerName)
+	}
+
+	return o
+}
+
+type outputs map[string]string
+
+// set : adds a value to the outputs, skipping the ones not known yet
+func (o outputs) set(collection, name, field, value string) {
+	if value == "" {
+		return
+	}
+	o[collection+"."+name+"."+field] = value
+}
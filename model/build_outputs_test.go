@@ -0,0 +1,40 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOutputs(t *testing.T) {
+	Convey("Given a build", t, func() {
+		var b Build
+		err := json.Unmarshal([]byte(`{
+			"instances": [{"name": "web-1", "instance_aws_id": "i-0001", "public_ip": "52.0.0.1", "ip": "10.0.0.11"}, {"name": "web-2", "ip": "10.0.0.12"}],
+			"elbs": [{"name": "api", "dns_name": "api-123.eu-west-1.elb.amazonaws.com"}]
+		}`), &b)
+		So(err, ShouldBeNil)
+
+		Convey("When I get its outputs", func() {
+			o := b.Outputs()
+
+			Convey("It should address every value by collection, name and field", func() {
+				So(o["instances.web-1.id"], ShouldEqual, "i-0001")
+				So(o["instances.web-1.public_ip"], ShouldEqual, "52.0.0.1")
+				So(o["instances.web-2.private_ip"], ShouldEqual, "10.0.0.12")
+				So(o["elbs.api.dns_name"], ShouldEqual, "api-123.eu-west-1.elb.amazonaws.com")
+			})
+
+			Convey("It should skip unknown values", func() {
+				_, ok := o["instances.web-2.public_ip"]
+				So(ok, ShouldBeFalse)
+				So(len(o), ShouldEqual, 5)
+			})
+		})
+	})
+}
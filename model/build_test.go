@@ -0,0 +1,88 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildCollections(t *testing.T) {
+	Convey("Given a build payload with component types not mapped on the build", t, func() {
+		var b Build
+		err := json.Unmarshal([]byte(`{
+			"id": "c3d9a1f0",
+			"roles": ["owner"],
+			"elbs": [{"name": "api", "dns_name": "api.elb.amazonaws.com"}],
+			"s3_buckets": [{"name": "assets", "acl": "private"}],
+			"route53_zones": []
+		}`), &b)
+		So(err, ShouldBeNil)
+
+		Convey("It should keep the mapped fields", func() {
+			So(b.ID, ShouldEqual, "c3d9a1f0")
+			So(len(b.ELBs), ShouldEqual, 1)
+		})
+
+		Convey("It should list every component collection", func() {
+			So(b.Collections(), ShouldResemble, []string{"elbs", "s3_buckets"})
+
+			buckets, err := b.Collection("s3_buckets")
+			So(err, ShouldBeNil)
+			So(buckets[0]["acl"], ShouldEqual, "private")
+		})
+	})
+}
+
+func TestBuildHistory(t *testing.T) {
+	Convey("Given the builds of an environment, newest first", t, func() {
+		builds := []Build{
+			{ID: "3", Status: "errored", Definition: "_metadata:\n  revert_of: \"1\"\n"},
+			{ID: "2", Status: "done", Definition: "name: app\n_metadata:\n  git_commit: 9fceb02d0ae598e95dc970b74767f19372d61af8\n  git_dirty: \"true\"\n  message: bump web\n"},
+			{ID: "1", Status: "done"},
+		}
+
+		Convey("When I get its history", func() {
+			entries := BuildHistory(builds)
+
+			Convey("It should index the builds from the oldest", func() {
+				So(entries[0].Index, ShouldEqual, 3)
+				So(entries[2].Index, ShouldEqual, 1)
+			})
+
+			Convey("It should mark the newest done build as current", func() {
+				So(entries[0].Current, ShouldBeFalse)
+				So(entries[1].Current, ShouldBeTrue)
+				So(entries[2].Current, ShouldBeFalse)
+			})
+
+			Convey("It should describe the reverted build by its index", func() {
+				So(entries[0].RevertOf, ShouldEqual, "1")
+			})
+
+			Convey("It should describe the commit and message builds were applied with", func() {
+				So(entries[1].Commit, ShouldEqual, "9fceb02d+")
+				So(entries[1].Message, ShouldEqual, "bump web")
+				So(entries[2].Commit, ShouldEqual, "")
+			})
+		})
+
+		Convey("It should strip the metadata from definitions", func() {
+			So(builds[1].DefinitionWithoutMetadata(), ShouldEqual, "name: app\n")
+		})
+	})
+
+	Convey("Given two consecutive builds", t, func() {
+		var previous, current Build
+		So(json.Unmarshal([]byte(`{"instances": [{"name": "web-1", "ip": "10.0.0.11", "_state": "done"}, {"name": "web-2", "ip": "10.0.0.12"}]}`), &previous), ShouldBeNil)
+		So(json.Unmarshal([]byte(`{"instances": [{"name": "web-1", "ip": "10.0.0.11", "_state": "running"}, {"name": "web-3", "ip": "10.0.0.13"}], "elbs": [{"name": "api", "dns_name": "api.elb"}]}`), &current), ShouldBeNil)
+
+		Convey("It should count the components added, changed and removed", func() {
+			So(current.ChangesSince(&previous), ShouldResemble, BuildChanges{Added: 2, Changed: 0, Removed: 1})
+		})
+	})
+}
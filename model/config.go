@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"path"
 	"strings"
 
 	"github.com/mitchellh/go-homedir"
@@ -21,6 +22,34 @@ type Config struct {
 	Token  string `json:"token"`
 	User   string `json:"user"`
 	UserID string `json:"userid"`
+	// Protected are the project/env patterns of the environments that
+	// need a typed confirmation to be destroyed
+	Protected []string `json:"protected,omitempty"`
+	// Sandboxes are the project/env patterns of the environments that
+	// env watch can apply changes to
+	Sandboxes []string `json:"sandboxes,omitempty"`
+	// Hooks are the local commands to run around every apply and destroy
+	Hooks Hooks `json:"hooks,omitempty"`
+}
+
+// IsProtected : returns true when an environment matches a protection pattern
+func (c *Config) IsProtected(project, env string) bool {
+	return matchesEnv(c.Protected, project, env)
+}
+
+// IsSandbox : returns true when an environment matches a sandbox pattern,
+// protected environments are never sandboxes
+func (c *Config) IsSandbox(project, env string) bool {
+	return matchesEnv(c.Sandboxes, project, env) && !c.IsProtected(project, env)
+}
+
+func matchesEnv(patterns []string, project, env string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, project+"/"+env); ok {
+			return true
+		}
+	}
+	return false
 }
 
 // GetConfig : Get config defined on the .ernest file
@@ -0,0 +1,37 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIsProtected(t *testing.T) {
+	Convey("Given a config with protection patterns", t, func() {
+		c := Config{Protected: []string{"*/production", "shop/prod*"}}
+
+		Convey("It should protect the environments matching any pattern", func() {
+			So(c.IsProtected("billing", "production"), ShouldBeTrue)
+			So(c.IsProtected("shop", "prod-eu"), ShouldBeTrue)
+		})
+
+		Convey("It should not protect other environments", func() {
+			So(c.IsProtected("shop", "staging"), ShouldBeFalse)
+			So(c.IsProtected("billing", "prod-eu"), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given a config with sandbox patterns", t, func() {
+		c := Config{Sandboxes: []string{"shop/*"}, Protected: []string{"*/production"}}
+
+		Convey("It should only allow unprotected environments as sandboxes", func() {
+			So(c.IsSandbox("shop", "dev-1"), ShouldBeTrue)
+			So(c.IsSandbox("shop", "production"), ShouldBeFalse)
+			So(c.IsSandbox("billing", "dev-1"), ShouldBeFalse)
+		})
+	})
+}
@@ -6,8 +6,10 @@ package model
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	yaml "gopkg.in/yaml.v2"
 )
@@ -41,10 +43,10 @@ func (d *Definition) Load(data []byte) (err error) {
 	err = yaml.Unmarshal(data, &d.data)
 	for _, item := range d.data {
 		if item.Key == "name" {
-			d.Name = item.Value.(string)
+			d.Name, _ = item.Value.(string)
 		}
 		if item.Key == "project" {
-			d.Project = item.Value.(string)
+			d.Project, _ = item.Value.(string)
 		}
 	}
 
@@ -63,6 +65,94 @@ func (d *Definition) LoadFileImports() error {
 	return err
 }
 
+// ResolveFileImports : makes the files referenced with a relative path
+// relative to the given directory, usually the one of the definition file.
+// References only found relative to the working directory are kept as they are
+func (d *Definition) ResolveFileImports(dir string) {
+	var resolve func(v interface{}) interface{}
+	resolve = func(v interface{}) interface{} {
+		switch x := v.(type) {
+		case string:
+			if len(x) > 3 && x[:2] == "@{" && x[len(x)-1] == '}' {
+				return "@{" + resolveImportPath(dir, x[2:len(x)-1]) + "}"
+			}
+		case yaml.MapSlice:
+			for i, item := range x {
+				x[i].Value = resolve(item.Value)
+			}
+		case []interface{}:
+			for i, item := range x {
+				x[i] = resolve(item)
+			}
+		}
+		return v
+	}
+	resolve(d.data)
+}
+
+// resolveImportPath : returns the path of a referenced file relative to the
+// given directory, unless it only exists relative to the working directory
+func resolveImportPath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	resolved := filepath.Join(dir, path)
+	if _, err := os.Stat(resolved); err == nil {
+		return resolved
+	}
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+
+	return resolved
+}
+
+// Validate : checks the definition locally, before sending it to ernest
+func (d *Definition) Validate() error {
+	if d.Name == "" {
+		return errors.New("The definition should have a name")
+	}
+	if d.Project == "" {
+		return errors.New("The definition should have a project")
+	}
+
+	_, err := d.Hooks()
+	return err
+}
+
+// FileImports : returns the paths of the files referenced with @{...}, in
+// the order they are found
+func (d *Definition) FileImports() []string {
+	var paths []string
+	seen := make(map[string]bool)
+
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch x := v.(type) {
+		case string:
+			if len(x) > 3 && x[:2] == "@{" && x[len(x)-1] == '}' {
+				path := x[2 : len(x)-1]
+				if !seen[path] {
+					seen[path] = true
+					paths = append(paths, path)
+				}
+			}
+		case yaml.MapSlice:
+			for _, item := range x {
+				walk(item.Value)
+			}
+		case []interface{}:
+			for _, item := range x {
+				walk(item)
+			}
+		}
+	}
+	walk(d.data)
+
+	return paths
+}
+
 // AttachMap : will attach the contents of a map to the end of the definition
 func (d *Definition) AttachMap(key string, m map[string]string) {
 	x := yaml.MapSlice{}
@@ -91,6 +181,68 @@ func (d *Definition) AttachFile(key, path string) (err error) {
 	return
 }
 
+// MetadataKey is the reserved definition section where the client stores
+// information about how a build was produced
+const MetadataKey = "_metadata"
+
+// Metadata values
+const (
+	MetadataRevertOf  = "revert_of"
+	MetadataGitCommit = "git_commit"
+	MetadataGitBranch = "git_branch"
+	MetadataGitDirty  = "git_dirty"
+	MetadataGitRemote = "git_remote"
+	MetadataMessage   = "message"
+)
+
+// Metadata : returns the values stored on the definition metadata section
+func (d *Definition) Metadata() map[string]string {
+	m := make(map[string]string)
+	for _, item := range d.data {
+		if item.Key != MetadataKey {
+			continue
+		}
+		values, _ := item.Value.(yaml.MapSlice)
+		for _, v := range values {
+			m[fmt.Sprint(v.Key)] = fmt.Sprint(v.Value)
+		}
+	}
+	return m
+}
+
+// SetMetadata : sets a value on the definition metadata section
+func (d *Definition) SetMetadata(key, value string) {
+	for i, item := range d.data {
+		if item.Key != MetadataKey {
+			continue
+		}
+		values, _ := item.Value.(yaml.MapSlice)
+		for j, v := range values {
+			if v.Key == key {
+				values[j].Value = value
+				return
+			}
+		}
+		d.data[i].Value = append(values, yaml.MapItem{Key: key, Value: value})
+		return
+	}
+	d.data = append(d.data, yaml.MapItem{
+		Key:   MetadataKey,
+		Value: yaml.MapSlice{{Key: key, Value: value}},
+	})
+}
+
+// StripMetadata : removes the metadata section from the definition
+func (d *Definition) StripMetadata() {
+	var data yaml.MapSlice
+	for _, item := range d.data {
+		if item.Key != MetadataKey {
+			data = append(data, item)
+		}
+	}
+	d.data = data
+}
+
 // LoadMapSlice : loads all values into a slice
 func LoadMapSlice(s yaml.MapSlice) (yaml.MapSlice, error) {
 	var err error
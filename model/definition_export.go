@@ -0,0 +1,172 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// computedFields are the fields set by ernest or the provider, that can't
+// be declared on a definition
+var computedFields = map[string]bool{
+	"id":          true,
+	"arn":         true,
+	"status":      true,
+	"state":       true,
+	"public_ip":   true,
+	"private_ip":  true,
+	"public_dns":  true,
+	"private_dns": true,
+	"dns_name":    true,
+	"endpoint":    true,
+	"created_at":  true,
+	"updated_at":  true,
+}
+
+// computedSuffixes are the suffixes of the provider ids of components
+var computedSuffixes = []string{"_aws_id", "_azure_id", "_arn"}
+
+// Blob : a large value moved out of a definition to a file
+type Blob struct {
+	Path    string
+	Content string
+}
+
+// Clean : removes the client metadata, the ids and computed fields of the
+// components and the empty values of a definition
+func (d *Definition) Clean() {
+	d.StripMetadata()
+	d.StripHooks()
+	d.data = cleanMapSlice(d.data)
+}
+
+// ExtractBlobs : replaces the values longer than size with @{file}
+// references, naming files after their component and field on dir
+func (d *Definition) ExtractBlobs(dir string, size int) []Blob {
+	var blobs []Blob
+	used := make(map[string]bool)
+
+	for i, item := range d.data {
+		collection := fmt.Sprint(item.Key)
+		components, ok := item.Value.([]interface{})
+		if !ok {
+			continue
+		}
+		for j, c := range components {
+			fields, ok := c.(yaml.MapSlice)
+			if !ok {
+				continue
+			}
+			name := collection + "-" + fmt.Sprint(j+1)
+			for _, f := range fields {
+				if f.Key == "name" {
+					name = collection + "-" + fmt.Sprint(f.Value)
+				}
+			}
+			for k, f := range fields {
+				value, ok := f.Value.(string)
+				if !ok || len(value) <= size {
+					continue
+				}
+
+				path := blobPath(dir, name+"-"+fmt.Sprint(f.Key), value, used)
+				blobs = append(blobs, Blob{Path: path, Content: value})
+				fields[k].Value = "@{" + path + "}"
+			}
+			components[j] = fields
+		}
+		d.data[i].Value = components
+	}
+
+	return blobs
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// blobPath : returns an unused file path for a blob, with an extension
+// matching its content
+func blobPath(dir, name, content string, used map[string]bool) string {
+	ext := ".txt"
+	switch {
+	case strings.HasPrefix(content, "#!"):
+		ext = ".sh"
+	case strings.HasPrefix(content, "#cloud-config"):
+		ext = ".yml"
+	case strings.HasPrefix(strings.TrimSpace(content), "{"):
+		ext = ".json"
+	}
+
+	base := unsafeFileChars.ReplaceAllString(name, "_")
+	path := filepath.Join(dir, base+ext)
+	for i := 2; used[path]; i++ {
+		path = filepath.Join(dir, base+"-"+fmt.Sprint(i)+ext)
+	}
+	used[path] = true
+
+	return path
+}
+
+func isComputedField(key string) bool {
+	if computedFields[key] || strings.HasPrefix(key, "_") {
+		return true
+	}
+	for _, suffix := range computedSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func cleanMapSlice(s yaml.MapSlice) yaml.MapSlice {
+	var cleaned yaml.MapSlice
+	for _, item := range s {
+		if isComputedField(fmt.Sprint(item.Key)) {
+			continue
+		}
+		value := cleanValue(item.Value)
+		if isEmptyDefinitionValue(value) {
+			continue
+		}
+		cleaned = append(cleaned, yaml.MapItem{Key: item.Key, Value: value})
+	}
+	return cleaned
+}
+
+func cleanValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case yaml.MapSlice:
+		return cleanMapSlice(x)
+	case []interface{}:
+		var cleaned []interface{}
+		for _, item := range x {
+			value := cleanValue(item)
+			if !isEmptyDefinitionValue(value) {
+				cleaned = append(cleaned, value)
+			}
+		}
+		return cleaned
+	}
+	return v
+}
+
+func isEmptyDefinitionValue(v interface{}) bool {
+	switch x := v.(type) {
+	case nil:
+		return true
+	case string:
+		return x == ""
+	case yaml.MapSlice:
+		return len(x) == 0
+	case []interface{}:
+		return len(x) == 0
+	}
+	return false
+}
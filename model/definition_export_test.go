@@ -0,0 +1,61 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDefinitionExport(t *testing.T) {
+	Convey("Given the definition of an imported environment", t, func() {
+		userData := "#!/bin/sh\n" + strings.Repeat("echo setting up\n", 10)
+
+		var d Definition
+		err := d.Load([]byte(`name: app
+project: shop
+id: 42
+_metadata:
+  revert_of: "1"
+instances:
+  - name: web
+    type: t2.micro
+    instance_aws_id: i-0001
+    public_ip: 52.0.0.1
+    _state: done
+    elastic_ip: ""
+    security_groups: []
+    user_data: "` + strings.Replace(userData, "\n", `\n`, -1) + `"
+`))
+		So(err, ShouldBeNil)
+
+		Convey("When I clean it", func() {
+			d.Clean()
+			data, err := d.Save()
+			So(err, ShouldBeNil)
+
+			Convey("It should remove the ids, computed fields and empty values", func() {
+				So(string(data), ShouldStartWith, "name: app\nproject: shop\ninstances:\n- name: web\n  type: t2.micro\n  user_data:")
+				So(string(data), ShouldNotContainSubstring, "i-0001")
+				So(string(data), ShouldNotContainSubstring, "52.0.0.1")
+				So(string(data), ShouldNotContainSubstring, "_metadata")
+				So(string(data), ShouldNotContainSubstring, "security_groups")
+			})
+
+			Convey("And I extract its blobs", func() {
+				blobs := d.ExtractBlobs("infra", 64)
+
+				Convey("It should reference them from files named after their component", func() {
+					So(blobs, ShouldResemble, []Blob{{Path: "infra/instances-web-user_data.sh", Content: userData}})
+					data, err := d.Save()
+					So(err, ShouldBeNil)
+					So(string(data), ShouldContainSubstring, "user_data: '@{infra/instances-web-user_data.sh}'")
+				})
+			})
+		})
+	})
+}
@@ -36,3 +36,81 @@ func TestEvent(t *testing.T) {
 	})
 
 }
+
+func TestMetadata(t *testing.T) {
+	Convey("Given a loaded definition", t, func() {
+		var d Definition
+		p, err := ioutil.ReadFile("../internal/definitions/aws1.yml")
+		So(err, ShouldBeNil)
+		So(d.Load(p), ShouldBeNil)
+
+		Convey("When I set metadata values", func() {
+			d.SetMetadata("revert_of", "a1b2c3d4")
+			d.SetMetadata("revert_of", "c3d9a1f0")
+
+			Convey("It should store them on the metadata section", func() {
+				So(d.Metadata()["revert_of"], ShouldEqual, "c3d9a1f0")
+
+				output, err := d.Save()
+				So(err, ShouldBeNil)
+				So(string(output), ShouldContainSubstring, MetadataKey+":\n  revert_of: c3d9a1f0")
+			})
+
+			Convey("And I strip the metadata", func() {
+				d.StripMetadata()
+
+				Convey("It should not be saved", func() {
+					So(d.Metadata(), ShouldBeEmpty)
+					output, err := d.Save()
+					So(err, ShouldBeNil)
+					So(string(output), ShouldNotContainSubstring, MetadataKey)
+				})
+			})
+		})
+	})
+}
+
+func TestFileImports(t *testing.T) {
+	Convey("Given a definition referencing files", t, func() {
+		var d Definition
+		err := d.Load([]byte("name: app\nproject: shop\ninstances:\n  - name: web\n    user_data: '@{web.sh}'\n  - name: db\n    user_data: '@{db.sh}'\n  - name: worker\n    user_data: '@{web.sh}'\n"))
+		So(err, ShouldBeNil)
+
+		Convey("It should list every referenced file once", func() {
+			So(d.FileImports(), ShouldResemble, []string{"web.sh", "db.sh"})
+		})
+
+		Convey("When I resolve them against the directory of the definition", func() {
+			d.ResolveFileImports("infra")
+
+			Convey("It should reference them relative to that directory", func() {
+				So(d.FileImports(), ShouldResemble, []string{"infra/web.sh", "infra/db.sh"})
+			})
+		})
+
+		Convey("It should be valid", func() {
+			So(d.Validate(), ShouldBeNil)
+		})
+	})
+
+	Convey("Given a definition referencing a file of the working directory", t, func() {
+		var d Definition
+		err := d.Load([]byte("name: app\nproject: shop\nuser_data: '@{definition.go}'\n"))
+		So(err, ShouldBeNil)
+
+		Convey("It should keep the reference when resolving it", func() {
+			d.ResolveFileImports("infra")
+			So(d.FileImports(), ShouldResemble, []string{"definition.go"})
+		})
+	})
+
+	Convey("Given a definition without a name", t, func() {
+		var d Definition
+		err := d.Load([]byte("name: 5\nproject: shop\n"))
+		So(err, ShouldBeNil)
+
+		Convey("It should not be valid", func() {
+			So(d.Validate(), ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,146 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// driftIgnored are the fields describing where a component belongs, that
+// differ between an environment and its shadow
+var driftIgnored = map[string]bool{
+	"service":     true,
+	"environment": true,
+	"project":     true,
+}
+
+// Drift : the differences between the components of an applied build and
+// the ones discovered on the provider
+type Drift struct {
+	// Drifted are the components with fields that changed
+	Drifted []DriftedComponent
+	// Missing are the applied components that were not discovered
+	Missing []string
+	// Unmanaged are the discovered components that were never applied
+	Unmanaged []string
+}
+
+// DriftedComponent : a component with fields that changed on the provider
+type DriftedComponent struct {
+	Component string
+	Fields    []FieldDrift
+}
+
+// FieldDrift : a field with a different value on the provider
+type FieldDrift struct {
+	Field    string
+	Expected interface{}
+	Actual   interface{}
+}
+
+// Empty : returns true when nothing drifted
+func (d *Drift) Empty() bool {
+	return len(d.Drifted) == 0 && len(d.Missing) == 0 && len(d.Unmanaged) == 0
+}
+
+// DriftFrom : compares the components of an applied build with the ones
+// discovered on the provider, field by field. Fields the provider doesn't
+// report and fields not set on the applied build are not compared
+func (b *Build) DriftFrom(discovered *Build) Drift {
+	var d Drift
+
+	applied := b.components()
+	live := discovered.components()
+
+	for _, id := range sortedComponentIDs(applied) {
+		component, ok := live[id]
+		if !ok {
+			d.Missing = append(d.Missing, id)
+			continue
+		}
+
+		var fields []FieldDrift
+		for _, field := range sortedFields(applied[id]) {
+			expected := applied[id][field]
+			actual, ok := component[field]
+			if !ok || driftIgnored[field] || isEmptyValue(expected) {
+				continue
+			}
+			if !sameValue(expected, actual) {
+				fields = append(fields, FieldDrift{Field: field, Expected: expected, Actual: actual})
+			}
+		}
+
+		if len(fields) > 0 {
+			d.Drifted = append(d.Drifted, DriftedComponent{Component: id, Fields: fields})
+		}
+	}
+
+	for _, id := range sortedComponentIDs(live) {
+		if _, ok := applied[id]; !ok {
+			d.Unmanaged = append(d.Unmanaged, id)
+		}
+	}
+
+	return d
+}
+
+func sortedComponentIDs(components map[string]map[string]interface{}) []string {
+	ids := make([]string, 0, len(components))
+	for id := range components {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func sortedFields(component map[string]interface{}) []string {
+	fields := make([]string, 0, len(component))
+	for f := range component {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// sameValue : compares two field values, lists being equal when they have
+// the same items in any order, as providers don't keep their order
+func sameValue(a, b interface{}) bool {
+	la, ok := a.([]interface{})
+	lb, okb := b.([]interface{})
+	if !ok || !okb {
+		return reflect.DeepEqual(a, b)
+	}
+	if len(la) != len(lb) {
+		return false
+	}
+	return reflect.DeepEqual(sortedItems(la), sortedItems(lb))
+}
+
+func sortedItems(list []interface{}) []string {
+	items := make([]string, len(list))
+	for i, v := range list {
+		data, _ := json.Marshal(v)
+		items[i] = string(data)
+	}
+	sort.Strings(items)
+	return items
+}
+
+func isEmptyValue(v interface{}) bool {
+	switch x := v.(type) {
+	case nil:
+		return true
+	case string:
+		return x == ""
+	case []interface{}:
+		return len(x) == 0
+	case map[string]interface{}:
+		return len(x) == 0
+	}
+	return false
+}
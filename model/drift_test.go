@@ -0,0 +1,56 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDrift(t *testing.T) {
+	Convey("Given an applied build and the components discovered on the provider", t, func() {
+		var applied, discovered Build
+		So(json.Unmarshal([]byte(`{
+			"instances": [
+				{"name": "web-1", "instance_type": "t2.micro", "security_groups": ["web", "ssh"], "user_data": "", "service": "a1", "_state": "done"},
+				{"name": "web-2", "instance_type": "t2.micro"}
+			],
+			"s3_buckets": [{"name": "assets", "acl": "private"}]
+		}`), &applied), ShouldBeNil)
+		So(json.Unmarshal([]byte(`{
+			"instances": [
+				{"name": "web-1", "instance_type": "t2.large", "security_groups": ["ssh", "web"], "user_data": "#!/bin/sh", "service": "b2", "_state": "running"},
+				{"name": "bastion", "instance_type": "t2.nano"}
+			],
+			"s3_buckets": [{"name": "assets", "acl": "private", "bucket_location": "eu-west-1"}]
+		}`), &discovered), ShouldBeNil)
+
+		Convey("When I compare them", func() {
+			drift := applied.DriftFrom(&discovered)
+
+			Convey("It should report the fields that changed", func() {
+				So(drift.Drifted, ShouldResemble, []DriftedComponent{
+					{Component: "instances.web-1", Fields: []FieldDrift{{Field: "instance_type", Expected: "t2.micro", Actual: "t2.large"}}},
+				})
+			})
+
+			Convey("It should report the missing and unmanaged components", func() {
+				So(drift.Missing, ShouldResemble, []string{"instances.web-2"})
+				So(drift.Unmanaged, ShouldResemble, []string{"instances.bastion"})
+				So(drift.Empty(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When I compare it with itself", func() {
+			drift := applied.DriftFrom(&applied)
+
+			Convey("It should not drift", func() {
+				So(drift.Empty(), ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -0,0 +1,216 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Graph node statuses
+const (
+	GraphDone     = "done"
+	GraphErrored  = "errored"
+	GraphPending  = "pending"
+	GraphNotBuilt = "not_built"
+)
+
+// graphReferences are the fields of a component referencing components of
+// another collection by name
+var graphReferences = map[string]string{
+	"vpc":             "vpcs",
+	"network":         "networks",
+	"networks":        "networks",
+	"subnets":         "networks",
+	"public_network":  "networks",
+	"routed_networks": "networks",
+	"security_groups": "security_groups",
+	"instances":       "instances",
+	"nat_gateway":     "nats",
+	"volume":          "ebs_volumes",
+}
+
+// Graph : the components of a definition and the references between them
+type Graph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+// GraphNode : a component of a definition
+type GraphNode struct {
+	ID         string
+	Collection string
+	Name       string
+	// Status is the status of the component on a build, if known
+	Status string
+}
+
+// GraphEdge : a component referencing another one through a field
+type GraphEdge struct {
+	From  string
+	To    string
+	Field string
+}
+
+// Graph : returns the components of the definition and their references,
+// on the order they are declared
+func (d *Definition) Graph() Graph {
+	var g Graph
+	ids := make(map[string]bool)
+
+	for _, item := range d.data {
+		collection := fmt.Sprint(item.Key)
+		for _, c := range definitionComponents(item.Value) {
+			name := fmt.Sprint(componentField(c, "name"))
+			id := collection + "." + name
+			if ids[id] {
+				continue
+			}
+			ids[id] = true
+			g.Nodes = append(g.Nodes, GraphNode{ID: id, Collection: collection, Name: name})
+		}
+	}
+
+	edges := make(map[GraphEdge]bool)
+	for _, item := range d.data {
+		collection := fmt.Sprint(item.Key)
+		for _, c := range definitionComponents(item.Value) {
+			from := collection + "." + fmt.Sprint(componentField(c, "name"))
+			for _, ref := range componentReferences(c) {
+				e := GraphEdge{From: from, To: ref.collection + "." + ref.name, Field: ref.field}
+				if ids[e.To] && e.To != from && !edges[e] {
+					edges[e] = true
+					g.Edges = append(g.Edges, e)
+				}
+			}
+		}
+	}
+
+	return g
+}
+
+// SetStatus : sets the status of every node from the components of a build,
+// instances declared with a count being built as <name>-1 to <name>-N
+func (g *Graph) SetStatus(b *Build) {
+	for i, n := range g.Nodes {
+		components, err := b.Collection(n.Collection)
+		if err != nil {
+			g.Nodes[i].Status = GraphNotBuilt
+			continue
+		}
+
+		pattern := regexp.MustCompile("^" + regexp.QuoteMeta(n.Name) + "(-[0-9]+)?$")
+		status := GraphNotBuilt
+		for _, c := range components {
+			name, _ := c["name"].(string)
+			if !pattern.MatchString(name) {
+				continue
+			}
+			s := componentStatus(c, b.Status)
+			if status == GraphNotBuilt || s == GraphErrored || (s == GraphPending && status == GraphDone) {
+				status = s
+			}
+		}
+		g.Nodes[i].Status = status
+	}
+}
+
+// componentStatus : classifies the state of a build component
+func componentStatus(c map[string]interface{}, build string) string {
+	state, _ := c["_state"].(string)
+	if state == "" {
+		state = build
+	}
+
+	switch state {
+	case "completed", "done":
+		return GraphDone
+	case "errored", "failed":
+		return GraphErrored
+	}
+	return GraphPending
+}
+
+type reference struct {
+	field      string
+	collection string
+	name       string
+}
+
+// componentReferences : returns the references of a component, including
+// the ones of its nested values, like the volumes of an instance
+func componentReferences(c yaml.MapSlice) []reference {
+	var refs []reference
+
+	for _, f := range c {
+		field := fmt.Sprint(f.Key)
+		if collection, ok := graphReferences[field]; ok {
+			for _, name := range referencedNames(f.Value) {
+				refs = append(refs, reference{field: field, collection: collection, name: name})
+			}
+			continue
+		}
+
+		switch v := f.Value.(type) {
+		case yaml.MapSlice:
+			refs = append(refs, componentReferences(v)...)
+		case []interface{}:
+			for _, item := range v {
+				if m, ok := item.(yaml.MapSlice); ok {
+					refs = append(refs, componentReferences(m)...)
+				}
+			}
+		}
+	}
+
+	return refs
+}
+
+// referencedNames : returns the names a reference field holds, as a name,
+// a list of names, or components with a name, like vcloud networks
+func referencedNames(v interface{}) []string {
+	switch x := v.(type) {
+	case string:
+		return []string{x}
+	case yaml.MapSlice:
+		if name, ok := componentField(x, "name").(string); ok {
+			return []string{name}
+		}
+	case []interface{}:
+		var names []string
+		for _, item := range x {
+			names = append(names, referencedNames(item)...)
+		}
+		return names
+	}
+	return nil
+}
+
+// definitionComponents : returns the components of a definition collection
+func definitionComponents(v interface{}) []yaml.MapSlice {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var components []yaml.MapSlice
+	for _, item := range list {
+		if c, ok := item.(yaml.MapSlice); ok && componentField(c, "name") != nil {
+			components = append(components, c)
+		}
+	}
+	return components
+}
+
+func componentField(c yaml.MapSlice, key string) interface{} {
+	for _, f := range c {
+		if f.Key == key {
+			return f.Value
+		}
+	}
+	return nil
+}
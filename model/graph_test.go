@@ -0,0 +1,74 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGraph(t *testing.T) {
+	Convey("Given a definition with components referencing each other", t, func() {
+		var d Definition
+		err := d.Load([]byte(`name: app
+project: shop
+vpcs:
+  - name: main
+networks:
+  - name: web
+    vpc: main
+    nat_gateway: gw
+nats:
+  - name: gw
+    public_network: web
+instances:
+  - name: web
+    count: 2
+    network: web
+    security_groups: [web-sg, missing-sg]
+    volumes:
+      - volume: data
+ebs_volumes:
+  - name: data
+security_groups:
+  - name: web-sg
+`))
+		So(err, ShouldBeNil)
+
+		Convey("When I get its graph", func() {
+			g := d.Graph()
+
+			Convey("It should have a node per component", func() {
+				So(g.Nodes, ShouldHaveLength, 6)
+				So(g.Nodes[0], ShouldResemble, GraphNode{ID: "vpcs.main", Collection: "vpcs", Name: "main"})
+			})
+
+			Convey("It should connect the components referencing existing ones", func() {
+				So(g.Edges, ShouldResemble, []GraphEdge{
+					{From: "networks.web", To: "vpcs.main", Field: "vpc"},
+					{From: "networks.web", To: "nats.gw", Field: "nat_gateway"},
+					{From: "nats.gw", To: "networks.web", Field: "public_network"},
+					{From: "instances.web", To: "networks.web", Field: "network"},
+					{From: "instances.web", To: "security_groups.web-sg", Field: "security_groups"},
+					{From: "instances.web", To: "ebs_volumes.data", Field: "volume"},
+				})
+			})
+
+			Convey("And I set the status of a build", func() {
+				var b Build
+				So(json.Unmarshal([]byte(`{"status": "errored", "vpcs": [{"name": "main", "_state": "completed"}], "instances": [{"name": "web-1", "_state": "completed"}, {"name": "web-2", "_state": "errored"}]}`), &b), ShouldBeNil)
+				g.SetStatus(&b)
+
+				Convey("It should aggregate the status of every counted instance", func() {
+					So(g.Nodes[0].Status, ShouldEqual, GraphDone)
+					So(g.Nodes[3].Status, ShouldEqual, GraphErrored)
+					So(g.Nodes[1].Status, ShouldEqual, GraphNotBuilt)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,130 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Lifecycle hooks
+const (
+	HookPreApply    = "pre-apply"
+	HookPostApply   = "post-apply"
+	HookOnFailure   = "on-failure"
+	HookPreDestroy  = "pre-destroy"
+	HookPostDestroy = "post-destroy"
+)
+
+// HookNames lists the lifecycle hooks that can be declared
+var HookNames = []string{HookPreApply, HookPostApply, HookOnFailure, HookPreDestroy, HookPostDestroy}
+
+// HooksKey is the definition section declaring local hooks, it is never
+// sent to ernest
+const HooksKey = "hooks"
+
+// Hooks : the local commands to run on every lifecycle hook
+type Hooks map[string][]string
+
+// UnmarshalJSON : loads hooks declared as a single command or a list of them
+func (h *Hooks) UnmarshalJSON(data []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	hooks, err := parseHooks(raw)
+	if err != nil {
+		return err
+	}
+
+	*h = hooks
+	return nil
+}
+
+// Merge : returns the hooks with the commands of another set of hooks
+// appended, so they run after its own
+func (h Hooks) Merge(o Hooks) Hooks {
+	merged := make(Hooks)
+	for name, commands := range h {
+		merged[name] = append(merged[name], commands...)
+	}
+	for name, commands := range o {
+		merged[name] = append(merged[name], commands...)
+	}
+	return merged
+}
+
+// Hooks : returns the hooks declared on the definition
+func (d *Definition) Hooks() (Hooks, error) {
+	raw := make(map[string]interface{})
+	for _, item := range d.data {
+		if item.Key != HooksKey {
+			continue
+		}
+		values, ok := item.Value.(yaml.MapSlice)
+		if !ok {
+			return nil, errors.New("Invalid hooks section, it should map hook names to commands")
+		}
+		for _, v := range values {
+			raw[fmt.Sprint(v.Key)] = v.Value
+		}
+	}
+
+	return parseHooks(raw)
+}
+
+// StripHooks : removes the hooks section from the definition
+func (d *Definition) StripHooks() {
+	var data yaml.MapSlice
+	for _, item := range d.data {
+		if item.Key != HooksKey {
+			data = append(data, item)
+		}
+	}
+	d.data = data
+}
+
+// parseHooks : validates hooks declared as a single command or a list of them
+func parseHooks(raw map[string]interface{}) (Hooks, error) {
+	hooks := make(Hooks)
+
+	for name, value := range raw {
+		if !isHookName(name) {
+			return nil, errors.New("Invalid hook '" + name + "', valid hooks are " + strings.Join(HookNames, ", "))
+		}
+
+		switch v := value.(type) {
+		case nil:
+		case string:
+			hooks[name] = []string{v}
+		case []interface{}:
+			for _, command := range v {
+				s, ok := command.(string)
+				if !ok {
+					return nil, errors.New("Invalid " + name + " hook, commands should be strings")
+				}
+				hooks[name] = append(hooks[name], s)
+			}
+		default:
+			return nil, errors.New("Invalid " + name + " hook, it should be a command or a list of commands")
+		}
+	}
+
+	return hooks, nil
+}
+
+func isHookName(name string) bool {
+	for _, n := range HookNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
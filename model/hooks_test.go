@@ -0,0 +1,68 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHooks(t *testing.T) {
+	Convey("Given a definition declaring hooks", t, func() {
+		var d Definition
+		err := d.Load([]byte("name: app\nproject: shop\nhooks:\n  pre-apply: ./check.sh\n  post-apply:\n    - ./smoke.sh\n    - ./notify.sh done\n"))
+		So(err, ShouldBeNil)
+
+		Convey("When I get its hooks", func() {
+			hooks, err := d.Hooks()
+
+			Convey("It should accept a command or a list of them", func() {
+				So(err, ShouldBeNil)
+				So(hooks[HookPreApply], ShouldResemble, []string{"./check.sh"})
+				So(hooks[HookPostApply], ShouldResemble, []string{"./smoke.sh", "./notify.sh done"})
+			})
+		})
+
+		Convey("When I strip its hooks", func() {
+			d.StripHooks()
+			data, err := d.Save()
+
+			Convey("It should not send them", func() {
+				So(err, ShouldBeNil)
+				So(strings.Contains(string(data), "hooks"), ShouldBeFalse)
+				So(strings.Contains(string(data), "name: app"), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a definition declaring an unknown hook", t, func() {
+		var d Definition
+		err := d.Load([]byte("name: app\nhooks:\n  after-apply: ./smoke.sh\n"))
+		So(err, ShouldBeNil)
+
+		Convey("It should fail to get its hooks", func() {
+			_, err := d.Hooks()
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given hooks on the config and on a definition", t, func() {
+		var config Hooks
+		err := json.Unmarshal([]byte(`{"post-apply": "./config.sh", "on-failure": ["./page.sh"]}`), &config)
+		So(err, ShouldBeNil)
+
+		Convey("When I merge them", func() {
+			hooks := config.Merge(Hooks{HookPostApply: {"./definition.sh"}})
+
+			Convey("It should run the config commands first", func() {
+				So(hooks[HookPostApply], ShouldResemble, []string{"./config.sh", "./definition.sh"})
+				So(hooks[HookOnFailure], ShouldResemble, []string{"./page.sh"})
+			})
+		})
+	})
+}
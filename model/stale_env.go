@@ -0,0 +1,27 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import "time"
+
+// StaleEnv : an environment that can be garbage collected
+type StaleEnv struct {
+	Project string
+	Name    string
+	// Creator is the user that created the first build of the environment
+	Creator string
+	// Age is the time since the latest build of the environment was created
+	Age time.Duration
+	// Idle is the time since the latest build of the environment finished
+	Idle time.Duration
+	// Result describes the outcome of collecting the environment
+	Result string
+	Err    error
+}
+
+// FullName : returns the environment name with its project, as project/env
+func (e *StaleEnv) FullName() string {
+	return e.Project + "/" + e.Name
+}
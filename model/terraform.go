@@ -0,0 +1,685 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// TerraformFile : a terraform configuration or variables file
+type TerraformFile struct {
+	Path    string
+	Content []byte
+}
+
+// ConversionReport : the resources a conversion translated, and what it
+// couldn't translate
+type ConversionReport struct {
+	// Converted are the translated resources, as type.name
+	Converted []string
+	Issues    []ConversionIssue
+}
+
+// ConversionIssue : a block, attribute or expression not translated
+type ConversionIssue struct {
+	// Position is the file and line of the block, as file:line
+	Position string
+	Resource string
+	Field    string
+	Reason   string
+}
+
+// terraformCollections are the supported resource types, with the
+// definition collection they are translated to, on the order the
+// collections are written
+var terraformCollections = []struct {
+	Type       string
+	Collection string
+}{
+	{"aws_vpc", "vpcs"},
+	{"aws_subnet", "networks"},
+	{"aws_security_group", "security_groups"},
+	{"aws_nat_gateway", "nats"},
+	{"aws_instance", "instances"},
+	{"aws_ebs_volume", "ebs_volumes"},
+	{"aws_elb", "elbs"},
+	{"aws_db_instance", "rds_instances"},
+}
+
+var (
+	terraformInterpolation = regexp.MustCompile(`\$\{([^}]*)\}`)
+	terraformVariable      = regexp.MustCompile(`^var\.([\w-]+)$`)
+	terraformReference     = regexp.MustCompile(`^\$\{(\w+)\.([\w-]+)(\.\*)?\.(\w+)\}$`)
+)
+
+// tfBody : the attributes of a block, with its nested blocks as []tfBody
+type tfBody map[string]interface{}
+
+type tfResource struct {
+	Type     string
+	Name     string
+	Position string
+	Body     tfBody
+}
+
+type tfConverter struct {
+	variables map[string]interface{}
+	resources map[string]bool
+	report    *ConversionReport
+}
+
+// ConvertTerraform : translates the supported aws resources of terraform
+// configuration files to a definition. Variables are resolved with their
+// defaults, overridden by the values of .tfvars files
+func ConvertTerraform(name, project string, files []TerraformFile) (Definition, ConversionReport, error) {
+	var report ConversionReport
+	var resources []tfResource
+
+	c := tfConverter{
+		variables: make(map[string]interface{}),
+		resources: make(map[string]bool),
+		report:    &report,
+	}
+
+	var tfvars []*ast.ObjectList
+	for _, f := range files {
+		file, err := hcl.ParseBytes(f.Content)
+		if err != nil {
+			return Definition{}, report, errors.New("Can't parse " + f.Path + ", only the terraform 0.11 syntax is supported: " + err.Error())
+		}
+		list, ok := file.Node.(*ast.ObjectList)
+		if !ok {
+			continue
+		}
+		if filepath.Ext(f.Path) == ".tfvars" {
+			tfvars = append(tfvars, list)
+			continue
+		}
+		resources = append(resources, c.parse(f.Path, list)...)
+	}
+
+	for _, list := range tfvars {
+		for _, item := range list.Items {
+			if len(item.Keys) == 1 {
+				c.variables[tfKey(item.Keys[0])] = tfValue(item.Val)
+			}
+		}
+	}
+
+	for _, r := range resources {
+		c.resources[r.Type+"."+r.Name] = true
+	}
+
+	d := NewDefinition(name, project)
+	for _, tc := range terraformCollections {
+		var components []interface{}
+		for i := range resources {
+			r := &resources[i]
+			if r.Type != tc.Type {
+				continue
+			}
+			components = append(components, c.convert(r))
+			report.Converted = append(report.Converted, r.Type+"."+r.Name)
+		}
+		if len(components) > 0 {
+			d.AttachComponents(tc.Collection, components)
+		}
+	}
+
+	return d, report, nil
+}
+
+// parse : reads the resources and variables of a file, reporting any other
+// top level block
+func (c *tfConverter) parse(path string, list *ast.ObjectList) []tfResource {
+	var resources []tfResource
+
+	for _, item := range list.Items {
+		position := filepath.Base(path) + ":" + strconv.Itoa(item.Pos().Line)
+		kind := tfKey(item.Keys[0])
+
+		switch {
+		case kind == "variable" && len(item.Keys) == 2:
+			body := tfObject(item.Val)
+			if v, ok := body["default"]; ok {
+				c.variables[tfKey(item.Keys[1])] = v
+			}
+		case kind == "resource" && len(item.Keys) == 3:
+			r := tfResource{
+				Type:     tfKey(item.Keys[1]),
+				Name:     tfKey(item.Keys[2]),
+				Position: position,
+				Body:     tfObject(item.Val),
+			}
+			if terraformCollection(r.Type) == "" {
+				c.issue(position, r.Type+"."+r.Name, "", r.Type+" resources are not supported")
+				continue
+			}
+			resources = append(resources, r)
+		case kind == "provider":
+			c.issue(position, tfKeys(item.Keys), "", "set the provider credentials and region on the ernest project")
+		case kind == "data":
+			c.issue(position, tfKeys(item.Keys), "", "data sources are not supported")
+		default:
+			c.issue(position, tfKeys(item.Keys), "", kind+" blocks are not supported")
+		}
+	}
+
+	return resources
+}
+
+// convert : translates a resource to a component
+func (c *tfConverter) convert(r *tfResource) yaml.MapSlice {
+	x := c.component(r, r.Body, "")
+	x.set("name", r.Name)
+	x.ignore("depends_on")
+
+	switch r.Type {
+	case "aws_vpc":
+		x.set("subnet", x.value("cidr_block"))
+	case "aws_subnet":
+		x.set("vpc", x.ref("vpc_id", "aws_vpc"))
+		x.set("subnet", x.value("cidr_block"))
+		x.set("public", x.boolean("map_public_ip_on_launch"))
+		x.set("availability_zone", x.value("availability_zone"))
+	case "aws_security_group":
+		x.set("vpc", x.ref("vpc_id", "aws_vpc"))
+		x.set("ingress", x.rules("ingress"))
+		x.set("egress", x.rules("egress"))
+	case "aws_nat_gateway":
+		x.set("public_network", x.ref("subnet_id", "aws_subnet"))
+		x.ignore("allocation_id")
+		c.issue(r.Position, r.Type+"."+r.Name, "routed_networks", "the networks routed through the gateway are set on route tables, list them on routed_networks")
+	case "aws_instance":
+		x.set("type", x.value("instance_type"))
+		x.set("image", x.value("ami"))
+		if _, ok := r.Body["count"]; ok {
+			x.set("count", x.number("count"))
+		} else {
+			x.set("count", int64(1))
+		}
+		x.set("network", x.ref("subnet_id", "aws_subnet"))
+		x.set("start_ip", x.value("private_ip"))
+		x.set("key_pair", x.value("key_name"))
+		x.set("security_groups", x.refs("vpc_security_group_ids", "aws_security_group"))
+		x.set("user_data", x.value("user_data"))
+		if _, ok := r.Body["private_ip"]; !ok {
+			c.issue(r.Position, r.Type+"."+r.Name, "start_ip", "private_ip is not set, set the ip of the first instance on start_ip")
+		}
+	case "aws_ebs_volume":
+		x.set("type", x.value("type"))
+		x.set("size", x.number("size"))
+		x.set("iops", x.number("iops"))
+		x.set("availability_zone", x.value("availability_zone"))
+		x.set("encrypted", x.boolean("encrypted"))
+		x.set("encryption_key_id", x.value("kms_key_id"))
+	case "aws_elb":
+		x.set("private", x.boolean("internal"))
+		x.set("subnets", x.refs("subnets", "aws_subnet"))
+		x.set("instances", x.refs("instances", "aws_instance"))
+		x.set("security_groups", x.refs("security_groups", "aws_security_group"))
+		x.set("listeners", x.listeners())
+	case "aws_db_instance":
+		x.set("size", x.value("instance_class"))
+		x.set("engine", x.value("engine"))
+		x.set("engine_version", x.value("engine_version"))
+		x.set("port", x.number("port"))
+		x.set("public", x.boolean("publicly_accessible"))
+		x.set("multi_az", x.boolean("multi_az"))
+		x.set("availability_zone", x.value("availability_zone"))
+		x.set("database_name", x.value("name"))
+		x.set("username", x.value("username"))
+		x.set("password", x.value("password"))
+		x.set("security_groups", x.refs("vpc_security_group_ids", "aws_security_group"))
+		x.set("storage", tfSection(
+			yaml.MapItem{Key: "type", Value: x.value("storage_type")},
+			yaml.MapItem{Key: "size", Value: x.number("allocated_storage")},
+			yaml.MapItem{Key: "iops", Value: x.number("iops")},
+		))
+		x.set("backups", tfSection(
+			yaml.MapItem{Key: "window", Value: x.value("backup_window")},
+			yaml.MapItem{Key: "retention", Value: x.number("backup_retention_period")},
+		))
+		x.set("maintenance_window", x.value("maintenance_window"))
+		if skip, ok := x.boolean("skip_final_snapshot").(bool); ok {
+			x.set("final_snapshot", !skip)
+		}
+	}
+
+	return x.done()
+}
+
+func (c *tfConverter) issue(position, resource, field, reason string) {
+	c.report.Issues = append(c.report.Issues, ConversionIssue{
+		Position: position,
+		Resource: resource,
+		Field:    field,
+		Reason:   reason,
+	})
+}
+
+// resolve : replaces the variables of a string with their values, returning
+// false if any expression can't be resolved
+func (c *tfConverter) resolve(s string) (interface{}, bool) {
+	if m := terraformInterpolation.FindStringSubmatch(s); m != nil && m[0] == s {
+		if v := terraformVariable.FindStringSubmatch(strings.TrimSpace(m[1])); v != nil {
+			value, ok := c.variables[v[1]]
+			return value, ok && tfScalar(value)
+		}
+	}
+
+	resolved := true
+	out := terraformInterpolation.ReplaceAllStringFunc(s, func(expr string) string {
+		v := terraformVariable.FindStringSubmatch(strings.TrimSpace(expr[2 : len(expr)-1]))
+		if v == nil {
+			resolved = false
+			return expr
+		}
+		value, ok := c.variables[v[1]]
+		if !ok || !tfScalar(value) {
+			resolved = false
+			return expr
+		}
+		return fmt.Sprint(value)
+	})
+
+	return out, resolved
+}
+
+// resolveList : returns the value of a list variable referenced by a string
+func (c *tfConverter) resolveList(s string) ([]interface{}, bool) {
+	m := terraformInterpolation.FindStringSubmatch(s)
+	if m == nil || m[0] != s {
+		return nil, false
+	}
+	v := terraformVariable.FindStringSubmatch(strings.TrimSpace(m[1]))
+	if v == nil {
+		return nil, false
+	}
+	list, ok := c.variables[v[1]].([]interface{})
+	return list, ok
+}
+
+// tfComponent : builds a component from a block, reporting the attributes
+// it doesn't use
+type tfComponent struct {
+	c      *tfConverter
+	r      *tfResource
+	body   tfBody
+	prefix string
+	used   map[string]bool
+	data   yaml.MapSlice
+}
+
+func (c *tfConverter) component(r *tfResource, body tfBody, prefix string) *tfComponent {
+	return &tfComponent{c: c, r: r, body: body, prefix: prefix, used: make(map[string]bool)}
+}
+
+func (x *tfComponent) issue(field, reason string) {
+	x.c.issue(x.r.Position, x.r.Type+"."+x.r.Name, x.prefix+field, reason)
+}
+
+func (x *tfComponent) set(key string, value interface{}) {
+	if value == nil {
+		return
+	}
+	if l, ok := value.([]interface{}); ok && len(l) == 0 {
+		return
+	}
+	x.data = append(x.data, yaml.MapItem{Key: key, Value: value})
+}
+
+func (x *tfComponent) ignore(attrs ...string) {
+	for _, attr := range attrs {
+		x.used[attr] = true
+	}
+}
+
+// value : returns an attribute with its variables resolved, or nil if it
+// isn't set or can't be resolved
+func (x *tfComponent) value(attr string) interface{} {
+	x.used[attr] = true
+	v, ok := x.body[attr]
+	if !ok {
+		return nil
+	}
+
+	switch t := v.(type) {
+	case string:
+		resolved, ok := x.c.resolve(t)
+		if !ok {
+			x.issue(attr, "the expression "+t+" can't be resolved")
+			return nil
+		}
+		return resolved
+	case []interface{}, []tfBody, tfBody:
+		x.issue(attr, "expected a single value")
+		return nil
+	}
+
+	return v
+}
+
+// number : returns a numeric attribute, converting numeric strings, as
+// terraform does
+func (x *tfComponent) number(attr string) interface{} {
+	v := x.value(attr)
+	if s, ok := v.(string); ok {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			x.issue(attr, "expected a number, got "+s)
+			return nil
+		}
+		return n
+	}
+	return v
+}
+
+// boolean : returns a boolean attribute, converting boolean strings, as
+// terraform does
+func (x *tfComponent) boolean(attr string) interface{} {
+	v := x.value(attr)
+	if s, ok := v.(string); ok {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			x.issue(attr, "expected a boolean, got "+s)
+			return nil
+		}
+		return b
+	}
+	return v
+}
+
+// ref : returns the name of the component a reference attribute points to
+func (x *tfComponent) ref(attr, kind string) interface{} {
+	x.used[attr] = true
+	v, ok := x.body[attr]
+	if !ok {
+		return nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		x.issue(attr, "expected a reference to a "+kind)
+		return nil
+	}
+	name, ok := x.reference(attr, s, kind)
+	if !ok {
+		return nil
+	}
+	return name
+}
+
+// refs : returns the names of the components a list of references points to
+func (x *tfComponent) refs(attr, kind string) interface{} {
+	x.used[attr] = true
+	v, ok := x.body[attr]
+	if !ok {
+		return nil
+	}
+	list, ok := v.([]interface{})
+	if !ok {
+		list = []interface{}{v}
+	}
+
+	var names []interface{}
+	seen := make(map[string]bool)
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			x.issue(attr, "expected a reference to a "+kind)
+			continue
+		}
+		if name, ok := x.reference(attr, s, kind); ok && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (x *tfComponent) reference(attr, s, kind string) (string, bool) {
+	m := terraformReference.FindStringSubmatch(s)
+	if m == nil {
+		x.issue(attr, "the expression "+s+" is not a reference to a "+kind)
+		return "", false
+	}
+	target := m[1] + "." + m[2]
+	if m[1] != kind || !x.c.resources[target] {
+		x.issue(attr, "references "+target+", which is not converted")
+		return "", false
+	}
+	if m[4] != "id" && m[4] != "name" {
+		x.issue(attr, "references the "+m[4]+" attribute of "+target)
+		return "", false
+	}
+	return m[2], true
+}
+
+// rules : translates the ingress or egress blocks of a security group, with
+// a rule for each cidr block
+func (x *tfComponent) rules(block string) interface{} {
+	x.used[block] = true
+	var rules []interface{}
+
+	for i, b := range tfBlocks(x.body[block]) {
+		id := block + "[" + strconv.Itoa(i) + "]"
+		rule := x.c.component(x.r, b, id+".")
+		protocol := rule.value("protocol")
+		if fmt.Sprint(protocol) == "-1" {
+			protocol = "any"
+		}
+		from := rule.value("from_port")
+		to := rule.value("to_port")
+		cidrs := rule.cidrs()
+		rule.ignore("description")
+		rule.done()
+
+		if protocol == nil || from == nil || to == nil {
+			x.issue(id, "the rule is not converted, its protocol and ports should be set")
+			continue
+		}
+		if len(cidrs) == 0 {
+			x.issue(id, "the rule is not converted, rules without cidr blocks are not supported")
+			continue
+		}
+
+		for _, ip := range cidrs {
+			rules = append(rules, yaml.MapSlice{
+				{Key: "ip", Value: ip},
+				{Key: "protocol", Value: protocol},
+				{Key: "from_port", Value: fmt.Sprint(from)},
+				{Key: "to_port", Value: fmt.Sprint(to)},
+			})
+		}
+	}
+
+	return rules
+}
+
+// cidrs : returns the resolved cidr blocks of a rule, given as a list or as
+// a list variable
+func (x *tfComponent) cidrs() []string {
+	x.used["cidr_blocks"] = true
+	v, ok := x.body["cidr_blocks"]
+	if !ok {
+		return nil
+	}
+
+	list, ok := v.([]interface{})
+	if s, isString := v.(string); isString {
+		list, ok = x.c.resolveList(s)
+	}
+	if !ok {
+		x.issue("cidr_blocks", "the expression "+fmt.Sprint(v)+" can't be resolved")
+		return nil
+	}
+
+	var cidrs []string
+	for _, cidr := range list {
+		s, ok := cidr.(string)
+		if ok {
+			var resolved interface{}
+			resolved, ok = x.c.resolve(s)
+			s = fmt.Sprint(resolved)
+		}
+		if !ok {
+			x.issue("cidr_blocks", "the expression "+fmt.Sprint(cidr)+" can't be resolved")
+			continue
+		}
+		cidrs = append(cidrs, s)
+	}
+
+	return cidrs
+}
+
+// listeners : translates the listener blocks of an elb
+func (x *tfComponent) listeners() interface{} {
+	x.used["listener"] = true
+	var listeners []interface{}
+
+	for i, b := range tfBlocks(x.body["listener"]) {
+		l := x.c.component(x.r, b, "listener["+strconv.Itoa(i)+"].")
+		protocol, _ := l.value("lb_protocol").(string)
+		protocol = strings.ToUpper(protocol)
+		if p, ok := l.value("instance_protocol").(string); ok && strings.ToUpper(p) != protocol {
+			l.issue("instance_protocol", "the instances are reached with the listener protocol "+protocol)
+		}
+		l.set("from_port", l.number("lb_port"))
+		l.set("to_port", l.number("instance_port"))
+		if protocol != "" {
+			l.set("protocol", protocol)
+		}
+		l.set("ssl_cert", l.value("ssl_certificate_id"))
+		listeners = append(listeners, l.done())
+	}
+
+	return listeners
+}
+
+// done : reports the attributes and blocks not used, returning the component
+func (x *tfComponent) done() yaml.MapSlice {
+	var unused []string
+	for attr := range x.body {
+		if !x.used[attr] {
+			unused = append(unused, attr)
+		}
+	}
+	sort.Strings(unused)
+
+	for _, attr := range unused {
+		if _, ok := x.body[attr].([]tfBody); ok {
+			x.issue(attr, attr+" blocks are not supported")
+			continue
+		}
+		x.issue(attr, "has no ernest equivalent")
+	}
+
+	return x.data
+}
+
+// AttachComponents : will attach a collection of components to the end of
+// the definition
+func (d *Definition) AttachComponents(key string, components []interface{}) {
+	d.data = append(d.data, yaml.MapItem{
+		Key:   key,
+		Value: components,
+	})
+}
+
+func terraformCollection(kind string) string {
+	for _, tc := range terraformCollections {
+		if tc.Type == kind {
+			return tc.Collection
+		}
+	}
+	return ""
+}
+
+// tfObject : reads the attributes and nested blocks of a block
+func tfObject(node ast.Node) tfBody {
+	body := make(tfBody)
+	o, ok := node.(*ast.ObjectType)
+	if !ok {
+		return body
+	}
+
+	for _, item := range o.List.Items {
+		key := tfKey(item.Keys[0])
+		if _, block := item.Val.(*ast.ObjectType); block && item.Assign.Line == 0 {
+			blocks, _ := body[key].([]tfBody)
+			body[key] = append(blocks, tfObject(item.Val))
+			continue
+		}
+		body[key] = tfValue(item.Val)
+	}
+
+	return body
+}
+
+// tfValue : reads the value of an attribute
+func tfValue(node ast.Node) interface{} {
+	switch n := node.(type) {
+	case *ast.LiteralType:
+		return n.Token.Value()
+	case *ast.ListType:
+		list := []interface{}{}
+		for _, item := range n.List {
+			list = append(list, tfValue(item))
+		}
+		return list
+	case *ast.ObjectType:
+		return tfObject(n)
+	}
+	return nil
+}
+
+func tfBlocks(v interface{}) []tfBody {
+	blocks, _ := v.([]tfBody)
+	return blocks
+}
+
+func tfKey(k *ast.ObjectKey) string {
+	return fmt.Sprint(k.Token.Value())
+}
+
+func tfKeys(keys []*ast.ObjectKey) string {
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, tfKey(k))
+	}
+	return strings.Join(parts, ".")
+}
+
+func tfScalar(v interface{}) bool {
+	switch v.(type) {
+	case string, int64, float64, bool:
+		return true
+	}
+	return false
+}
+
+// tfSection : returns the items set, or nil if none is
+func tfSection(items ...yaml.MapItem) interface{} {
+	var section yaml.MapSlice
+	for _, item := range items {
+		if item.Value != nil {
+			section = append(section, item)
+		}
+	}
+	if len(section) == 0 {
+		return nil
+	}
+	return section
+}
@@ -0,0 +1,220 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const terraformConfig = `
+provider "aws" {
+  region = "eu-west-1"
+}
+
+variable "cidr" {
+  default = "10.0.0.0/16"
+}
+
+variable "instances" {
+  default = 1
+}
+
+resource "aws_vpc" "main" {
+  cidr_block = "${var.cidr}"
+}
+
+resource "aws_subnet" "web" {
+  vpc_id                  = "${aws_vpc.main.id}"
+  cidr_block              = "10.0.1.0/24"
+  map_public_ip_on_launch = true
+}
+
+resource "aws_security_group" "web" {
+  vpc_id = "${aws_vpc.main.id}"
+
+  ingress {
+    from_port   = 80
+    to_port     = 80
+    protocol    = "tcp"
+    cidr_blocks = ["0.0.0.0/0", "10.0.0.0/16"]
+  }
+}
+
+resource "aws_instance" "web" {
+  ami                    = "ami-6666f915"
+  instance_type          = "t2.micro"
+  count                  = "${var.instances}"
+  subnet_id              = "${aws_subnet.web.id}"
+  private_ip             = "10.0.1.11"
+  vpc_security_group_ids = ["${aws_security_group.web.id}"]
+  iam_instance_profile   = "${aws_iam_instance_profile.web.name}"
+}
+
+resource "aws_elb" "web" {
+  subnets   = ["${aws_subnet.web.id}"]
+  instances = ["${aws_instance.web.*.id}"]
+
+  listener {
+    lb_port           = 80
+    lb_protocol       = "http"
+    instance_port     = "8080"
+    instance_protocol = "http"
+  }
+}
+
+resource "aws_route_table" "public" {
+  vpc_id = "${aws_vpc.main.id}"
+}
+`
+
+func TestConvertTerraform(t *testing.T) {
+	Convey("Given a terraform configuration", t, func() {
+		files := []TerraformFile{
+			{Path: "main.tf", Content: []byte(terraformConfig)},
+			{Path: "terraform.tfvars", Content: []byte(`instances = "2"`)},
+		}
+
+		Convey("When I convert it", func() {
+			d, report, err := ConvertTerraform("app", "shop", files)
+			So(err, ShouldBeNil)
+			out, _ := d.Save()
+
+			Convey("It should translate the supported resources", func() {
+				So(report.Converted, ShouldResemble, []string{"aws_vpc.main", "aws_subnet.web", "aws_security_group.web", "aws_instance.web", "aws_elb.web"})
+				So(string(out), ShouldEqual, `name: app
+project: shop
+vpcs:
+- name: main
+  subnet: 10.0.0.0/16
+networks:
+- name: web
+  vpc: main
+  subnet: 10.0.1.0/24
+  public: true
+security_groups:
+- name: web
+  vpc: main
+  ingress:
+  - ip: 0.0.0.0/0
+    protocol: tcp
+    from_port: "80"
+    to_port: "80"
+  - ip: 10.0.0.0/16
+    protocol: tcp
+    from_port: "80"
+    to_port: "80"
+instances:
+- name: web
+  type: t2.micro
+  image: ami-6666f915
+  count: 2
+  network: web
+  start_ip: 10.0.1.11
+  security_groups:
+  - web
+elbs:
+- name: web
+  subnets:
+  - web
+  instances:
+  - web
+  listeners:
+  - from_port: 80
+    to_port: 8080
+    protocol: HTTP
+`)
+			})
+
+			Convey("It should report what it couldn't translate", func() {
+				So(report.Issues, ShouldResemble, []ConversionIssue{
+					{Position: "main.tf:2", Resource: "provider.aws", Reason: "set the provider credentials and region on the ernest project"},
+					{Position: "main.tf:57", Resource: "aws_route_table.public", Reason: "aws_route_table resources are not supported"},
+					{Position: "main.tf:35", Resource: "aws_instance.web", Field: "iam_instance_profile", Reason: "has no ernest equivalent"},
+				})
+			})
+		})
+
+		Convey("When I convert security group rules with expressions", func() {
+			files = []TerraformFile{{Path: "sg.tf", Content: []byte(`
+variable "cidrs" {
+  default = ["10.0.0.0/8", "172.16.0.0/12"]
+}
+
+resource "aws_security_group" "db" {
+  egress {
+    from_port   = 0
+    to_port     = 0
+    protocol    = -1
+    cidr_blocks = "${var.cidrs}"
+  }
+
+  ingress {
+    from_port   = "${var.port}"
+    to_port     = 5432
+    protocol    = "tcp"
+    cidr_blocks = ["10.0.0.0/8"]
+  }
+
+  ingress {
+    from_port       = 5432
+    to_port         = 5432
+    protocol        = "tcp"
+    security_groups = ["sg-12345"]
+  }
+
+  ingress {
+    from_port   = 22
+    to_port     = 22
+    protocol    = "-1"
+    cidr_blocks = "${var.admins}"
+  }
+}
+`)}}
+			d, report, err := ConvertTerraform("app", "shop", files)
+			So(err, ShouldBeNil)
+			out, _ := d.Save()
+
+			Convey("It should resolve list variables and translate -1 as any protocol", func() {
+				So(string(out), ShouldEqual, `name: app
+project: shop
+security_groups:
+- name: db
+  egress:
+  - ip: 10.0.0.0/8
+    protocol: any
+    from_port: "0"
+    to_port: "0"
+  - ip: 172.16.0.0/12
+    protocol: any
+    from_port: "0"
+    to_port: "0"
+`)
+			})
+
+			Convey("It should report the rules it couldn't translate", func() {
+				So(report.Issues, ShouldResemble, []ConversionIssue{
+					{Position: "sg.tf:6", Resource: "aws_security_group.db", Field: "ingress[0].from_port", Reason: "the expression ${var.port} can't be resolved"},
+					{Position: "sg.tf:6", Resource: "aws_security_group.db", Field: "ingress[0]", Reason: "the rule is not converted, its protocol and ports should be set"},
+					{Position: "sg.tf:6", Resource: "aws_security_group.db", Field: "ingress[1].security_groups", Reason: "has no ernest equivalent"},
+					{Position: "sg.tf:6", Resource: "aws_security_group.db", Field: "ingress[1]", Reason: "the rule is not converted, rules without cidr blocks are not supported"},
+					{Position: "sg.tf:6", Resource: "aws_security_group.db", Field: "ingress[2].cidr_blocks", Reason: "the expression ${var.admins} can't be resolved"},
+					{Position: "sg.tf:6", Resource: "aws_security_group.db", Field: "ingress[2]", Reason: "the rule is not converted, rules without cidr blocks are not supported"},
+				})
+			})
+		})
+
+		Convey("When a file uses a syntax it can't parse", func() {
+			files = append(files, TerraformFile{Path: "vpc.tf", Content: []byte(`resource "aws_vpc" "main" { cidr_block = var.cidr }`)})
+			_, _, err := ConvertTerraform("app", "shop", files)
+
+			Convey("It should fail naming the file", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "vpc.tf")
+			})
+		})
+	})
+}
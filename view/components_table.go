@@ -0,0 +1,132 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/olekukonko/tablewriter"
+)
+
+// maxDefaultColumns limits the columns shown for collections without a layout
+const maxDefaultColumns = 6
+
+// identifyingFields are shown first on collections without a layout
+var identifyingFields = []string{"id", "ip", "endpoint", "dns", "url", "arn", "address"}
+
+// printComponents : renders a component collection as a table
+func printComponents(name string, components []map[string]interface{}, columns []string) {
+	if columns == nil {
+		columns = defaultColumns(components)
+	}
+
+	fmt.Println("\n" + collectionTitle(name) + ":")
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(columns)
+	for _, c := range components {
+		var row []string
+		for _, column := range columns {
+			row = append(row, formatValue(c[column]))
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
+
+// defaultColumns : chooses the columns of a collection without a layout, its
+// name followed by its identifying fields and the rest of its plain fields
+func defaultColumns(components []map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	var fields []string
+
+	for _, c := range components {
+		for k, v := range c {
+			if seen[k] || strings.HasPrefix(k, "_") || !isScalar(v) {
+				continue
+			}
+			seen[k] = true
+			fields = append(fields, k)
+		}
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		ri, rj := fieldRank(fields[i]), fieldRank(fields[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return fields[i] < fields[j]
+	})
+
+	if len(fields) > maxDefaultColumns {
+		fields = fields[:maxDefaultColumns]
+	}
+
+	return fields
+}
+
+func fieldRank(field string) int {
+	if field == "name" {
+		return 0
+	}
+	for _, part := range strings.Split(field, "_") {
+		for _, f := range identifyingFields {
+			if part == f {
+				return 1
+			}
+		}
+	}
+	return 2
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case string, float64, bool:
+		return true
+	}
+	return false
+}
+
+func formatValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	}
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+// collectionTitle : converts a collection name to a title, s3_buckets to S3 buckets
+func collectionTitle(name string) string {
+	title := strings.Replace(name, "_", " ", -1)
+	if title == "" {
+		return title
+	}
+	return strings.ToUpper(title[:1]) + title[1:]
+}
+
+func isKnownCollection(name string) bool {
+	for _, known := range model.KnownCollections {
+		if known == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+)
+
+// PrintConversionReport : Pretty print for what a conversion translated and
+// what it couldn't
+func PrintConversionReport(report model.ConversionReport) {
+	fmt.Println("")
+	fmt.Println("Converted:")
+	for _, r := range report.Converted {
+		fmt.Println(" - " + r)
+	}
+
+	if len(report.Issues) == 0 {
+		fmt.Println("")
+		color.Green("Everything was converted")
+		return
+	}
+
+	fmt.Println("\nNot converted:")
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Position", "Resource", "Field", "Reason"})
+	table.SetAutoWrapText(false)
+	for _, i := range report.Issues {
+		table.Append([]string{i.Position, i.Resource, i.Field, i.Reason})
+	}
+	table.Render()
+
+	color.Yellow("\n" + strconv.Itoa(len(report.Converted)) + " resources converted, " + strconv.Itoa(len(report.Issues)) + " issues to review before applying the definition")
+}
@@ -0,0 +1,38 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/olekukonko/tablewriter"
+)
+
+// PrintDestroySummary : Pretty print for the components destroying an
+// environment will delete
+func PrintDestroySummary(build *model.Build) {
+	count := 0
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Type", "Name"})
+	for _, name := range build.Collections() {
+		components, _ := build.Collection(name)
+		for _, c := range components {
+			table.Append([]string{collectionTitle(name), formatValue(c["name"])})
+			count++
+		}
+	}
+
+	if count == 0 {
+		fmt.Println("The latest build of this environment has no components")
+		return
+	}
+
+	fmt.Println("The following " + strconv.Itoa(count) + " components will be deleted:")
+	table.Render()
+}
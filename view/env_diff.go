@@ -15,8 +15,8 @@ import (
 func PrintEnvDiff(build1 model.Build, build2 model.Build) {
 
 	diff := difflib.UnifiedDiff{
-		A:        difflib.SplitLines(build1.Definition),
-		B:        difflib.SplitLines(build2.Definition),
+		A:        difflib.SplitLines(build1.DefinitionWithoutMetadata()),
+		B:        difflib.SplitLines(build2.DefinitionWithoutMetadata()),
 		FromFile: "Original : " + build1.ID,
 		ToFile:   "Current : " + build2.ID,
 		Context:  3,
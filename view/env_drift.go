@@ -0,0 +1,51 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+)
+
+// PrintEnvDrift : Pretty print for the drift of an environment
+func PrintEnvDrift(name string, drift model.Drift) {
+	fmt.Println("")
+	if drift.Empty() {
+		color.Green("No drift found, " + name + " matches its latest applied build")
+		return
+	}
+
+	if len(drift.Drifted) > 0 {
+		fmt.Println("Drifted:")
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Component", "Field", "Expected", "Actual"})
+		for _, c := range drift.Drifted {
+			for _, f := range c.Fields {
+				table.Append([]string{c.Component, f.Field, formatValue(f.Expected), formatValue(f.Actual)})
+			}
+		}
+		table.Render()
+	}
+
+	printDriftList("Missing, applied but not found:", drift.Missing)
+	printDriftList("Unmanaged, found but never applied:", drift.Unmanaged)
+
+	fmt.Println("\n" + strconv.Itoa(len(drift.Drifted)) + " drifted, " + strconv.Itoa(len(drift.Missing)) + " missing and " + strconv.Itoa(len(drift.Unmanaged)) + " unmanaged components")
+}
+
+func printDriftList(title string, components []string) {
+	if len(components) == 0 {
+		return
+	}
+	fmt.Println("\n" + title)
+	for _, c := range components {
+		fmt.Println(" - " + c)
+	}
+}
@@ -1,20 +1,13 @@
 package view
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/fatih/color"
 )
 
 // EnvDry : Pretty print for env Dry
-func EnvDry(body string) {
-	var lines []string
-	if err := json.Unmarshal([]byte(body), &lines); err != nil {
-		color.Red("Unexpected response from ernest")
-		return
-	}
-
+func EnvDry(lines []string) {
 	if len(lines) == 0 {
 		fmt.Println("")
 		color.Green("This definition is up to date with latest changes. Nothing will be applied")
@@ -23,10 +16,15 @@ func EnvDry(body string) {
 	}
 
 	color.Green("Applying this definition will:")
+	PrintEnvChanges(lines)
+	fmt.Println("If you're agree with these changes please rerun apply without --dry option")
+}
+
+// PrintEnvChanges : Pretty print for the changes a build will perform
+func PrintEnvChanges(lines []string) {
 	fmt.Println("")
 	for i := range lines {
 		fmt.Println(" - " + lines[i])
 	}
 	fmt.Println("")
-	fmt.Println("If you're agree with these changes please rerun apply without --dry option")
 }
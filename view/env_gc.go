@@ -0,0 +1,57 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/olekukonko/tablewriter"
+)
+
+// PrintStaleEnvs : Pretty print for the environments to garbage collect
+func PrintStaleEnvs(envs []model.StaleEnv) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Environment", "Age", "Idle", "Created by"})
+	for _, e := range envs {
+		table.Append([]string{e.FullName(), formatAge(e.Age), formatAge(e.Idle), e.Creator})
+	}
+	table.Render()
+}
+
+// PrintGCReport : Pretty print for the outcome of a garbage collection
+func PrintGCReport(envs []model.StaleEnv) {
+	failed := 0
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Environment", "Result", "Error"})
+	for _, e := range envs {
+		message := ""
+		if e.Err != nil {
+			message = e.Err.Error()
+			failed++
+		}
+		table.Append([]string{e.FullName(), e.Result, message})
+	}
+
+	fmt.Println("\nGarbage collection report:")
+	table.Render()
+	fmt.Println(strconv.Itoa(len(envs)-failed) + " environments destroyed, " + strconv.Itoa(failed) + " failed")
+}
+
+// formatAge : formats a long duration in days and hours
+func formatAge(d time.Duration) string {
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+
+	if days == 0 {
+		return (d / time.Minute * time.Minute).String()
+	}
+
+	return strconv.Itoa(days) + "d " + strconv.Itoa(hours) + "h"
+}
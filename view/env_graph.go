@@ -0,0 +1,97 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ernestio/ernest-cli/model"
+)
+
+// Graph formats
+const (
+	GRAPHDOT     = "dot"
+	GRAPHMERMAID = "mermaid"
+)
+
+// GraphFormats lists the accepted graph formats
+var GraphFormats = []string{GRAPHDOT, GRAPHMERMAID}
+
+// graphColors are the fill colors of the nodes by status
+var graphColors = map[string]string{
+	model.GraphDone:     "#c8e6c9",
+	model.GraphErrored:  "#ffcdd2",
+	model.GraphPending:  "#fff9c4",
+	model.GraphNotBuilt: "#eeeeee",
+}
+
+// PrintEnvGraph : prints the components graph of an environment, coloring
+// the nodes by their status when known
+func PrintEnvGraph(name string, g model.Graph, format string) error {
+	switch format {
+	case GRAPHDOT:
+		printDotGraph(name, g)
+	case GRAPHMERMAID:
+		printMermaidGraph(g)
+	default:
+		return errors.New("Invalid graph format '" + format + "', valid formats are " + strings.Join(GraphFormats, ", "))
+	}
+	return nil
+}
+
+func printDotGraph(name string, g model.Graph) {
+	fmt.Println("digraph " + strconv.Quote(name) + " {")
+	fmt.Println("  rankdir=LR;")
+	fmt.Println(`  node [shape=box, style="rounded", fontname="Helvetica"];`)
+	fmt.Println(`  edge [fontname="Helvetica", fontsize=10];`)
+
+	for _, n := range g.Nodes {
+		attrs := "label=" + strconv.Quote(n.Name+"\n"+n.Collection)
+		if color, ok := graphColors[n.Status]; ok {
+			attrs += `, style="rounded,filled", fillcolor=` + strconv.Quote(color)
+		}
+		fmt.Println("  " + strconv.Quote(n.ID) + " [" + attrs + "];")
+	}
+	for _, e := range g.Edges {
+		fmt.Println("  " + strconv.Quote(e.From) + " -> " + strconv.Quote(e.To) + " [label=" + strconv.Quote(e.Field) + "];")
+	}
+
+	fmt.Println("}")
+}
+
+var mermaidUnsafe = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+func mermaidID(id string) string {
+	return mermaidUnsafe.ReplaceAllString(id, "_")
+}
+
+func printMermaidGraph(g model.Graph) {
+	fmt.Println("graph LR")
+
+	for _, n := range g.Nodes {
+		fmt.Println("  " + mermaidID(n.ID) + `["` + n.Name + "<br/>" + n.Collection + `"]`)
+	}
+	for _, e := range g.Edges {
+		fmt.Println("  " + mermaidID(e.From) + " -->|" + e.Field + "| " + mermaidID(e.To))
+	}
+
+	statuses := []string{model.GraphDone, model.GraphErrored, model.GraphPending, model.GraphNotBuilt}
+	for _, s := range statuses {
+		var ids []string
+		for _, n := range g.Nodes {
+			if n.Status == s {
+				ids = append(ids, mermaidID(n.ID))
+			}
+		}
+		if len(ids) > 0 {
+			fmt.Println("  classDef " + s + " fill:" + graphColors[s])
+			fmt.Println("  class " + strings.Join(ids, ",") + " " + s)
+		}
+	}
+}
@@ -8,25 +8,80 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/ernestio/ernest-cli/model"
 	"github.com/olekukonko/tablewriter"
 )
 
 // PrintEnvHistory : Pretty print for build history
-func PrintEnvHistory(name string, builds []model.Build) {
-	if len(builds) == 0 {
+func PrintEnvHistory(name string, entries []model.HistoryEntry, total int) {
+	if len(entries) == 0 && total > 0 {
+		fmt.Println("\nThere are no builds on this page, " + strconv.Itoa(total) + " builds match")
+		fmt.Println("")
+	} else if len(entries) == 0 {
 		fmt.Println("\nThere are no registered builds for this environment")
 		fmt.Println("")
 	} else {
+		withChanges := entries[0].Changes != nil
+
+		// commits and messages are only shown when builds have them
+		withCommits, withMessages, withDirty := false, false, false
+		for _, e := range entries {
+			withCommits = withCommits || e.Commit != ""
+			withMessages = withMessages || e.Message != ""
+			withDirty = withDirty || strings.HasSuffix(e.Commit, "+")
+		}
+
+		header := []string{"ID", "Build", "Name", "Status", "Version", "Duration", "User", "Revert of"}
+		if withChanges {
+			header = append(header, "Changes")
+		}
+		if withCommits {
+			header = append(header, "Commit")
+		}
+		if withMessages {
+			header = append(header, "Message")
+		}
+
 		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"ID", "Name", "Status", "Version", "User"})
-		num := len(builds) + 1
-		for _, b := range builds {
-			num = num - 1
-			id := strconv.Itoa(num)
-			table.Append([]string{id, name, b.Status, b.CreatedAt, b.UserName})
+		table.SetHeader(header)
+		for _, e := range entries {
+			id := strconv.Itoa(e.Index)
+			if e.Current {
+				id = id + " *"
+			}
+
+			duration := ""
+			if d := e.Duration(); d > 0 {
+				duration = formatDuration(d)
+			}
+
+			row := []string{id, e.Build.ShortID(), name, e.Build.Status, e.Build.CreatedAt, duration, e.Build.UserName, e.RevertOf}
+			if withChanges {
+				row = append(row, e.Changes.String())
+			}
+			if withCommits {
+				row = append(row, e.Commit)
+			}
+			if withMessages {
+				row = append(row, e.Message)
+			}
+			table.Append(row)
 		}
 		table.Render()
+
+		for _, e := range entries {
+			if e.Current {
+				fmt.Println("* currently deployed build")
+				break
+			}
+		}
+		if withDirty {
+			fmt.Println("+ applied with uncommitted changes")
+		}
+		if len(entries) < total {
+			fmt.Println("Showing " + strconv.Itoa(len(entries)) + " of " + strconv.Itoa(total) + " builds")
+		}
 	}
 }
@@ -7,6 +7,7 @@ package view
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/ernestio/ernest-cli/model"
 	"github.com/olekukonko/tablewriter"
@@ -14,6 +15,12 @@ import (
 
 // PrintEnvInfo : Pretty print for build info
 func PrintEnvInfo(build *model.Build) {
+	PrintEnvInfoColumns(build, nil)
+}
+
+// PrintEnvInfoColumns : Pretty print for build info, showing the given
+// columns for each component collection
+func PrintEnvInfoColumns(build *model.Build, columns map[string][]string) {
 	fmt.Println("Name : " + build.Name)
 	fmt.Println("Status : " + build.Status)
 	fmt.Println("Project : " + build.ProjectName)
@@ -24,8 +31,9 @@ func PrintEnvInfo(build *model.Build) {
 		fmt.Println("  " + m)
 	}
 	fmt.Println("Date : " + build.CreatedAt)
+	printBuildMetadata(build.Metadata())
 
-	if len(build.VPCs) > 0 {
+	if len(build.VPCs) > 0 && columns["vpcs"] == nil {
 		fmt.Println("\nVPCs:")
 		table := tablewriter.NewWriter(os.Stdout)
 		table.SetHeader([]string{"Name", "ID", "Subnet"})
@@ -35,7 +43,7 @@ func PrintEnvInfo(build *model.Build) {
 		table.Render()
 	}
 
-	if len(build.ELBs) > 0 {
+	if len(build.ELBs) > 0 && columns["elbs"] == nil {
 		fmt.Println("\nELBs:")
 		table := tablewriter.NewWriter(os.Stdout)
 		table.SetHeader([]string{"Name", "DNS Name"})
@@ -45,7 +53,7 @@ func PrintEnvInfo(build *model.Build) {
 		table.Render()
 	}
 
-	if len(build.Networks) > 0 {
+	if len(build.Networks) > 0 && columns["networks"] == nil {
 		fmt.Println("\nNetworks:")
 		table := tablewriter.NewWriter(os.Stdout)
 		table.SetHeader([]string{"Name", "ID", "Availability Zone"})
@@ -55,7 +63,7 @@ func PrintEnvInfo(build *model.Build) {
 		table.Render()
 	}
 
-	if len(build.Instances) > 0 {
+	if len(build.Instances) > 0 && columns["instances"] == nil {
 		fmt.Println("\nInstances:")
 		table := tablewriter.NewWriter(os.Stdout)
 		table.SetHeader([]string{"Name", "ID", "Public IP", "Private IP"})
@@ -65,7 +73,7 @@ func PrintEnvInfo(build *model.Build) {
 		table.Render()
 	}
 
-	if len(build.Nats) > 0 {
+	if len(build.Nats) > 0 && columns["nats"] == nil {
 		fmt.Println("\nNAT gateways:")
 		table := tablewriter.NewWriter(os.Stdout)
 		table.SetHeader([]string{"Name", "ID", "IP"})
@@ -75,7 +83,7 @@ func PrintEnvInfo(build *model.Build) {
 		table.Render()
 	}
 
-	if len(build.SecurityGroups) > 0 {
+	if len(build.SecurityGroups) > 0 && columns["security_groups"] == nil {
 		fmt.Println("\nSecurity groups:")
 		table := tablewriter.NewWriter(os.Stdout)
 		table.SetHeader([]string{"Name", "Group ID"})
@@ -85,7 +93,7 @@ func PrintEnvInfo(build *model.Build) {
 		table.Render()
 	}
 
-	if len(build.RDSClusters) > 0 {
+	if len(build.RDSClusters) > 0 && columns["rds_clusters"] == nil {
 		fmt.Println("\nRDS Clusters:")
 		table := tablewriter.NewWriter(os.Stdout)
 		table.SetHeader([]string{"Name", "Endpoint"})
@@ -95,7 +103,7 @@ func PrintEnvInfo(build *model.Build) {
 		table.Render()
 	}
 
-	if len(build.RDSInstances) > 0 {
+	if len(build.RDSInstances) > 0 && columns["rds_instances"] == nil {
 		fmt.Println("\nRDS Instances:")
 		table := tablewriter.NewWriter(os.Stdout)
 		table.SetHeader([]string{"Name", "Endpoint"})
@@ -105,7 +113,7 @@ func PrintEnvInfo(build *model.Build) {
 		table.Render()
 	}
 
-	if len(build.EBSVolumes) > 0 {
+	if len(build.EBSVolumes) > 0 && columns["ebs_volumes"] == nil {
 		fmt.Println("\nEBS Volumes:")
 		table := tablewriter.NewWriter(os.Stdout)
 		table.SetHeader([]string{"Name", "Volume ID"})
@@ -115,7 +123,7 @@ func PrintEnvInfo(build *model.Build) {
 		table.Render()
 	}
 
-	if len(build.LoadBalancers) > 0 {
+	if len(build.LoadBalancers) > 0 && columns["load_balancers"] == nil {
 		fmt.Println("\nLoad Balancers:")
 		table := tablewriter.NewWriter(os.Stdout)
 		table.SetHeader([]string{"Name", "IP"})
@@ -125,7 +133,7 @@ func PrintEnvInfo(build *model.Build) {
 		table.Render()
 	}
 
-	if len(build.VirtualMachines) > 0 {
+	if len(build.VirtualMachines) > 0 && columns["virtual_machines"] == nil {
 		fmt.Println("\nVirtual Machines:")
 		table := tablewriter.NewWriter(os.Stdout)
 		table.SetHeader([]string{"Name", "Public IP", "Private IP"})
@@ -135,7 +143,7 @@ func PrintEnvInfo(build *model.Build) {
 		table.Render()
 	}
 
-	if len(build.SQLDatabases) > 0 {
+	if len(build.SQLDatabases) > 0 && columns["sql_databases"] == nil {
 		fmt.Println("\nSQL Databases:")
 		table := tablewriter.NewWriter(os.Stdout)
 		table.SetHeader([]string{"Name", "Server Name"})
@@ -145,4 +153,34 @@ func PrintEnvInfo(build *model.Build) {
 		table.Render()
 	}
 
+	for _, name := range build.Collections() {
+		if isKnownCollection(name) && columns[name] == nil {
+			continue
+		}
+		components, _ := build.Collection(name)
+		printComponents(name, components, columns[name])
+	}
+}
+
+// printBuildMetadata : prints where a build was applied from, if known
+func printBuildMetadata(metadata map[string]string) {
+	if commit := metadata[model.MetadataGitCommit]; commit != "" {
+		details := []string{}
+		if branch := metadata[model.MetadataGitBranch]; branch != "" {
+			details = append(details, branch)
+		}
+		if metadata[model.MetadataGitDirty] == "true" {
+			details = append(details, "with uncommitted changes")
+		}
+		if len(details) > 0 {
+			commit += " (" + strings.Join(details, ", ") + ")"
+		}
+		fmt.Println("Commit : " + commit)
+	}
+	if remote := metadata[model.MetadataGitRemote]; remote != "" {
+		fmt.Println("Remote : " + remote)
+	}
+	if message := metadata[model.MetadataMessage]; message != "" {
+		fmt.Println("Message : " + message)
+	}
 }
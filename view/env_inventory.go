@@ -0,0 +1,158 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ernestio/ernest-cli/model"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Inventory formats
+const (
+	INVENTORYINI  = "ansible-ini"
+	INVENTORYYAML = "ansible-yaml"
+	INVENTORYSSH  = "ssh-config"
+)
+
+// InventoryFormats lists the accepted inventory formats
+var InventoryFormats = []string{INVENTORYINI, INVENTORYYAML, INVENTORYSSH}
+
+// InventoryOptions : settings used to render an inventory
+type InventoryOptions struct {
+	Format   string
+	Private  bool
+	User     string
+	Identity string
+}
+
+var invalidGroupChars = regexp.MustCompile("[^A-Za-z0-9_]+")
+
+// PrintEnvInventory : prints the hosts of a build as an inventory
+func PrintEnvInventory(hosts []model.Host, opts InventoryOptions) error {
+	var reachable []model.Host
+	for _, h := range hosts {
+		if h.Address(opts.Private) == "" {
+			fmt.Fprintln(os.Stderr, "Skipping "+h.Name+" as it has no "+addressType(opts.Private)+" ip")
+			continue
+		}
+		reachable = append(reachable, h)
+	}
+
+	switch opts.Format {
+	case INVENTORYINI:
+		printAnsibleINI(reachable, opts)
+	case INVENTORYYAML:
+		return printAnsibleYAML(reachable, opts)
+	case INVENTORYSSH:
+		printSSHConfig(reachable, opts)
+	default:
+		return errors.New("Invalid inventory format '" + opts.Format + "', valid formats are " + strings.Join(InventoryFormats, ", "))
+	}
+
+	return nil
+}
+
+func printAnsibleINI(hosts []model.Host, opts InventoryOptions) {
+	for i, group := range hostGroups(hosts) {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Println("[" + groupName(group) + "]")
+		for _, h := range hosts {
+			if h.Group != group {
+				continue
+			}
+			line := h.Name + " ansible_host=" + h.Address(opts.Private)
+			if opts.User != "" {
+				line += " ansible_user=" + opts.User
+			}
+			if opts.Identity != "" {
+				line += " ansible_ssh_private_key_file=" + opts.Identity
+			}
+			fmt.Println(line)
+		}
+	}
+}
+
+func printAnsibleYAML(hosts []model.Host, opts InventoryOptions) error {
+	var children yaml.MapSlice
+
+	for _, group := range hostGroups(hosts) {
+		var members yaml.MapSlice
+		for _, h := range hosts {
+			if h.Group != group {
+				continue
+			}
+			vars := yaml.MapSlice{{Key: "ansible_host", Value: h.Address(opts.Private)}}
+			if opts.User != "" {
+				vars = append(vars, yaml.MapItem{Key: "ansible_user", Value: opts.User})
+			}
+			if opts.Identity != "" {
+				vars = append(vars, yaml.MapItem{Key: "ansible_ssh_private_key_file", Value: opts.Identity})
+			}
+			members = append(members, yaml.MapItem{Key: h.Name, Value: vars})
+		}
+		children = append(children, yaml.MapItem{Key: groupName(group), Value: yaml.MapSlice{{Key: "hosts", Value: members}}})
+	}
+
+	inventory := yaml.MapSlice{{Key: "all", Value: yaml.MapSlice{{Key: "children", Value: children}}}}
+
+	out, err := yaml.Marshal(inventory)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(out))
+
+	return nil
+}
+
+func printSSHConfig(hosts []model.Host, opts InventoryOptions) {
+	for i, h := range hosts {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Println("Host " + h.Name)
+		fmt.Println("  HostName " + h.Address(opts.Private))
+		if opts.User != "" {
+			fmt.Println("  User " + opts.User)
+		}
+		if opts.Identity != "" {
+			fmt.Println("  IdentityFile " + opts.Identity)
+		}
+	}
+}
+
+// hostGroups : returns the group names of the hosts in order of appearance
+func hostGroups(hosts []model.Host) []string {
+	var groups []string
+	seen := make(map[string]bool)
+
+	for _, h := range hosts {
+		if !seen[h.Group] {
+			seen[h.Group] = true
+			groups = append(groups, h.Group)
+		}
+	}
+
+	return groups
+}
+
+// groupName : converts an instance group name to a valid ansible group name
+func groupName(group string) string {
+	return invalidGroupChars.ReplaceAllString(group, "_")
+}
+
+func addressType(private bool) string {
+	if private {
+		return "private"
+	}
+	return "public"
+}
@@ -0,0 +1,73 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Outputs formats
+const (
+	OUTPUTSJSON   = "json"
+	OUTPUTSDOTENV = "dotenv"
+	OUTPUTSEXPORT = "export"
+)
+
+// OutputsFormats lists the accepted outputs formats
+var OutputsFormats = []string{OUTPUTSJSON, OUTPUTSDOTENV, OUTPUTSEXPORT}
+
+var invalidVariableChars = regexp.MustCompile("[^A-Z0-9_]+")
+
+// PrintEnvOutputs : prints the outputs of an environment on the given format
+func PrintEnvOutputs(outputs map[string]string, format string) error {
+	switch format {
+	case OUTPUTSJSON:
+		out, err := json.MarshalIndent(outputs, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+	case OUTPUTSDOTENV:
+		for _, k := range sortedKeys(outputs) {
+			fmt.Println(variableName(k) + "=" + dotenvQuote(outputs[k]))
+		}
+	case OUTPUTSEXPORT:
+		for _, k := range sortedKeys(outputs) {
+			fmt.Println("export " + variableName(k) + "=" + shellQuote(outputs[k]))
+		}
+	default:
+		return errors.New("Invalid outputs format '" + format + "', valid formats are " + strings.Join(OutputsFormats, ", "))
+	}
+
+	return nil
+}
+
+// variableName : converts an output key to an environment variable name
+func variableName(key string) string {
+	return strings.Trim(invalidVariableChars.ReplaceAllString(strings.ToUpper(key), "_"), "_")
+}
+
+func dotenvQuote(value string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(value) + `"`
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.Replace(value, "'", `'\''`, -1) + "'"
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,93 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ernestio/ernest-cli/helper"
+	"github.com/olekukonko/tablewriter"
+)
+
+// chartWidth is the number of columns of the timings chart bars
+const chartWidth = 50
+
+// PrintEnvTimings : Pretty print for the timings of a build
+func PrintEnvTimings(t *helper.BuildTimings) {
+	fmt.Println("Name : " + t.Name)
+	fmt.Println("Build ID : " + t.ID)
+	fmt.Println("Status : " + t.Status)
+	fmt.Println("Wall time : " + formatDuration(t.Duration()))
+
+	if len(t.Components) == 0 {
+		fmt.Println("\nThis build has no component events")
+		return
+	}
+
+	fmt.Println("\nSlowest component types:")
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Type", "Components", "Total", "Slowest"})
+	for _, v := range t.Types {
+		table.Append([]string{v.Type, strconv.Itoa(v.Count), formatDuration(v.Total), formatDuration(v.Slowest)})
+	}
+	table.Render()
+
+	fmt.Println("\nCritical path:")
+	for _, c := range t.CriticalPath() {
+		fmt.Println("  " + c.Type + " " + c.Name + " (" + formatDuration(c.Duration()) + ")")
+	}
+
+	fmt.Println("\nTimeline:")
+	printTimingsChart(t)
+}
+
+func printTimingsChart(t *helper.BuildTimings) {
+	longest := 0
+	for _, c := range t.Components {
+		if len(c.Type+" "+c.Name) > longest {
+			longest = len(c.Type + " " + c.Name)
+		}
+	}
+
+	scale := 0.0
+	if t.Duration() > 0 {
+		scale = float64(chartWidth) / float64(t.Duration())
+	}
+
+	for _, c := range t.Components {
+		bar := []rune(strings.Repeat(" ", chartWidth))
+
+		if !c.Start.IsZero() {
+			from := int(float64(c.Start.Sub(t.Start)) * scale)
+			to := int(float64(c.End.Sub(t.Start)) * scale)
+			if c.End.IsZero() {
+				to = chartWidth
+			}
+			for i := from; i <= to && i < chartWidth; i++ {
+				if i >= 0 {
+					bar[i] = '='
+				}
+			}
+		}
+
+		marker := " "
+		if c.Critical {
+			marker = "*"
+		}
+
+		fmt.Printf("%s %-"+strconv.Itoa(longest)+"s |%s| %s %s\n", marker, c.Type+" "+c.Name, string(bar), formatDuration(c.Duration()), c.State)
+	}
+
+	fmt.Println("\n  * component on the critical path")
+}
+
+// formatDuration : formats a duration rounded to seconds
+func formatDuration(d time.Duration) string {
+	return (d / time.Second * time.Second).String()
+}
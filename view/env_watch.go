@@ -0,0 +1,55 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// WatchStatus : the outcome of the last check of a watched definition
+type WatchStatus struct {
+	File    string
+	Imports []string
+	Env     string
+	At      time.Time
+	Changes []string
+	Err     error
+	Note    string
+}
+
+// PrintWatchStatus : prints the outcome of checking a watched definition,
+// to be redrawn in place on every check
+func PrintWatchStatus(w io.Writer, s WatchStatus) {
+	watched := s.File
+	if len(s.Imports) > 0 {
+		watched += ", " + strings.Join(s.Imports, ", ")
+	}
+
+	fmt.Fprintf(w, "Watching %s\n", watched)
+	fmt.Fprintf(w, "Last checked at %s\n\n", s.At.Format("15:04:05"))
+
+	switch {
+	case s.Err != nil:
+		fmt.Fprintln(w, color.RedString(s.Err.Error()))
+	case len(s.Changes) == 0:
+		fmt.Fprintln(w, color.GreenString("Environment "+s.Env+" is up to date with this definition"))
+	default:
+		fmt.Fprintln(w, color.YellowString("Applying this definition to "+s.Env+" will:"))
+		for _, c := range s.Changes {
+			fmt.Fprintln(w, " - "+c)
+		}
+	}
+
+	if s.Note != "" {
+		fmt.Fprintln(w, "\n"+s.Note)
+	}
+
+	fmt.Fprintln(w, "\nPress Ctrl+C to stop watching")
+}